@@ -11,11 +11,14 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/config"
 	"github.com/sveltinio/sveltin/helpers"
 	"github.com/sveltinio/sveltin/helpers/factory"
 	"github.com/sveltinio/sveltin/internal/composer"
+	"github.com/sveltinio/sveltin/internal/content"
 	sveltinerr "github.com/sveltinio/sveltin/internal/errors"
 	"github.com/sveltinio/sveltin/internal/markup"
 	"github.com/sveltinio/sveltin/internal/tpltypes"
@@ -30,6 +33,8 @@ import (
 var (
 	resourceNameForContent string
 	withSampleContent      bool
+	archetypeForContent    string
+	archetypeParams        []string
 )
 
 const (
@@ -63,6 +68,33 @@ As result:
 - a new "welcome" folder within "content/posts" is created
 - an index.svx file is placed there
 - a new "posts/welcome" folder created within the "static" folder to store images relative to the content
+
+Archetypes:
+
+A project can define its own templates for the content files within the "archetypes" folder,
+shared by all resources (archetypes/<name>.svx) or for a resource (archetypes/<resource>/<name>.svx),
+the latter wins. They are used with the --archetype flag, the "default" archetype when the flag is
+not set and no --sample:
+
+  sveltin add content talk-x --to talks --archetype talk --set speaker="Jane Doe" --set room=A1
+
+Archetypes are Go templates with the same functions as the embedded ones (ToSlug, ToTitle, Today)
+and have access to:
+
+- .Content.Name, .Content.Resource and .Content.Archetype
+- .Content.Params, the values set with --set key=value (e.g. {{ .Content.Params.speaker }}),
+  params not set are empty
+- .ProjectSettings, the settings from sveltin.json (e.g. {{ .ProjectSettings.BaseURL }})
+
+Example archetype (archetypes/talks/talk.svx):
+
+  ---
+  title: {{ .Content.Name | ToTitle }}
+  slug: {{ .Content.Name | ToSlug }}
+  speaker: {{ .Content.Params.speaker }}
+  created_at: {{ Today }}
+  draft: true
+  ---
 `,
 	Run: RunAddContentCmd,
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -88,6 +120,8 @@ func RunAddContentCmd(cmd *cobra.Command, args []string) {
 	utils.ExitIfError(err)
 
 	contentData := tpltypes.NewContentData(contentName, contentResource, withSampleContent)
+	archetypeContent, err := setContentArchetype(contentData)
+	utils.ExitIfError(err)
 
 	headingText := fmt.Sprintf("Adding '%s' as content to the '%s' resource", contentData.Name, contentData.Resource)
 	cfg.log.Plain(markup.H1(headingText))
@@ -114,6 +148,17 @@ func RunAddContentCmd(cmd *cobra.Command, args []string) {
 		err := addSampleCoverImage(contentData)
 		utils.ExitIfError(err)
 	}
+
+	// NEW FILE: content/<resource_name>/<content_name>/index.svx from the archetype
+	if contentData.Archetype != "" {
+		err := writeArchetypeContent(archetypeContent, contentData)
+		utils.ExitIfError(err)
+	}
+
+	// UPDATE THE SCHEMA: sveltin.json
+	err = addContentSchema(contentData.Resource)
+	utils.ExitIfError(err)
+
 	cfg.log.Success("Done\n")
 }
 
@@ -127,6 +172,14 @@ func contentCmdFlags(cmd *cobra.Command) {
 	utils.ExitIfError(err)
 	// sample flag
 	cmd.Flags().BoolVarP(&withSampleContent, "sample", "s", false, "Add sample content to the markdown file")
+	// archetype flag
+	cmd.Flags().StringVarP(&archetypeForContent, "archetype", "a", "", "Name of the project archetype used for the markdown file")
+	err = cmd.RegisterFlagCompletionFunc("archetype", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return helpers.GetArchetypes(cfg.fs, cfg.settings.GetArchetypesPath(), resourceNameForContent), cobra.ShellCompDirectiveNoFileComp
+	})
+	utils.ExitIfError(err)
+	// set flag
+	cmd.Flags().StringArrayVarP(&archetypeParams, "set", "", []string{}, "Value available to the archetype as key=value (can be repeated)")
 }
 
 func init() {
@@ -136,6 +189,67 @@ func init() {
 
 //=============================================================================
 
+// setContentArchetype sets the archetype and its params for the content and returns the content file
+// rendered from the archetype, when one is used. It is rendered before any file is written
+// so that a template error does not leave a content folder behind.
+func setContentArchetype(contentData *tpltypes.ContentData) ([]byte, error) {
+	if withSampleContent {
+		if archetypeForContent != "" {
+			return nil, errors.New("--sample and --archetype cannot be used together")
+		}
+		if len(archetypeParams) > 0 {
+			cfg.log.Warning("--set values are used by archetypes only")
+		}
+		return nil, nil
+	}
+	pathToArchetype, err := helpers.GetArchetypeFile(cfg.fs, cfg.settings.GetArchetypesPath(), contentData.Resource, archetypeForContent)
+	if err != nil {
+		return nil, err
+	}
+	if pathToArchetype == "" {
+		if len(archetypeParams) > 0 {
+			cfg.log.Warning("--set values are used by archetypes only")
+		}
+		return nil, nil
+	}
+	params, err := helpers.ParseArchetypeParams(archetypeParams)
+	if err != nil {
+		return nil, err
+	}
+	contentData.Archetype = strings.TrimSuffix(filepath.Base(pathToArchetype), helpers.ArchetypeExt)
+	contentData.Params = params
+	fileContent, err := helpers.RenderArchetype(cfg.fs, pathToArchetype, &config.TemplateData{
+		Name:            contentData.Name,
+		Settings:        cfg.settings,
+		ProjectSettings: &cfg.projectSettings,
+		Content:         contentData,
+	})
+	if err != nil {
+		return nil, sveltinerr.NewDefaultError(fmt.Errorf("%s: %w", pathToArchetype, err))
+	}
+	return fileContent, nil
+}
+
+// writeArchetypeContent saves the content file rendered from the archetype.
+func writeArchetypeContent(fileContent []byte, contentData *tpltypes.ContentData) error {
+	cfg.log.Info(fmt.Sprintf("Content file from the '%s' archetype", contentData.Archetype))
+	saveAs := filepath.Join(cfg.settings.GetContentPath(), contentData.Resource, contentData.Name, cfg.pathMaker.GetResourceContentFilename())
+	if _, _, err := content.ParseFrontmatter(saveAs, fileContent); err != nil {
+		cfg.log.Warning(err.Error())
+	}
+	return helpers.WriteContentToDisk(cfg.fs, saveAs, fileContent)
+}
+
+// addContentSchema adds the frontmatter schema for the resource to the project settings, if missing.
+func addContentSchema(resource string) error {
+	metadata := []string{}
+	for _, name := range helpers.GetResourceMetadataMap(cfg.fs, []string{resource}, cfg.pathMaker.GetPathToRoutes())[resource] {
+		metadata = append(metadata, utils.ToSnakeCase(name))
+	}
+	cfg.log.Info("Frontmatter schema")
+	return helpers.AddContentSchema(cfg.fs, ProjectSettingsFile, resource, metadata)
+}
+
 func makeContentFolderStructure(folderName string, contentData *tpltypes.ContentData) (*composer.Folder, error) {
 	switch folderName {
 	case ContentFolder:
@@ -155,10 +269,12 @@ func createContentLocalFolder(contentData *tpltypes.ContentData) *composer.Folde
 	contentFolder := cfg.fsManager.GetFolder(ContentFolder)
 	// NEW FOLDER content/<resource_name>/<content_name>
 	resourceContentFolder := cfg.fsManager.NewResourceContentFolder(contentData)
-	// NEW FILE: content/<resource_name>/<content_name>/index.svx
-	contentFile := cfg.fsManager.NewResourceContentFile(contentData)
+	// NEW FILE: content/<resource_name>/<content_name>/index.svx, written later when using an archetype
+	if contentData.Archetype == "" {
+		contentFile := cfg.fsManager.NewResourceContentFile(contentData)
+		resourceContentFolder.Add(contentFile)
+	}
 	// SET FOLDER STRUCTURE
-	resourceContentFolder.Add(contentFile)
 	contentFolder.Add(resourceContentFolder)
 
 	return contentFolder
@@ -115,6 +115,11 @@ func RunAddMetadataCmd(cmd *cobra.Command, args []string) {
 	err = projectFolder.Create(sfs)
 	utils.ExitIfError(err)
 
+	// UPDATE THE SCHEMA: sveltin.json
+	cfg.log.Info("Frontmatter schema")
+	err = helpers.AddSchemaField(cfg.fs, ProjectSettingsFile, metadataTemplateData.Resource, utils.ToSnakeCase(metadataTemplateData.Name), helpers.MetadataSchemaField(metadataTemplateData.Type))
+	utils.ExitIfError(err)
+
 	cfg.log.Success("Done\n")
 
 	// NEXT STEPS
@@ -0,0 +1,32 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/resources"
+)
+
+//=============================================================================
+
+var contentCmd = &cobra.Command{
+	Use:   "content",
+	Short: "Work on the content files of your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to work on the existing content files through its own subcommands.
+
+Run 'sveltin content -h' for further details.
+`,
+	ValidArgs:             []string{"enrich", "lint"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+}
+
+func init() {
+	rootCmd.AddCommand(contentCmd)
+}
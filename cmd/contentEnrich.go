@@ -0,0 +1,127 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/internal/content"
+	sveltinerr "github.com/sveltinio/sveltin/internal/errors"
+	"github.com/sveltinio/sveltin/internal/markup"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+var (
+	wordsPerMinute int
+	tocDepth       int
+	withDryRun     bool
+)
+
+//=============================================================================
+
+var contentEnrichCmd = &cobra.Command{
+	Use:   "enrich [resource]",
+	Short: "Add word count, reading time and table of contents to the frontmatter",
+	Long: resources.GetASCIIArt() + `
+Command used to compute word count, reading time and the table of contents for each content file
+and to save them in the frontmatter under the "sveltin" key, for all the resources or the given one:
+
+  sveltin:
+    word_count: 523
+    reading_time: 3
+    toc:
+      - level: 2
+        text: Getting started
+        id: getting-started
+
+The ids match the ones added to the headings by rehype-slug.
+
+Only the "sveltin" block is written: the other frontmatter lines and the body are left untouched
+and files are saved only when the values change, so it can safely run before every build.
+
+The --wpm flag sets the reading speed in words per minute.
+The --toc-depth flag sets the deepest heading level listed in the table of contents.
+The --dry-run flag lists the files to be updated without saving them.
+`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  RunContentEnrichCmd,
+}
+
+// RunContentEnrichCmd is the actual work function.
+func RunContentEnrichCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands either from a not valid directory or not latest sveltin version.
+	isValidProject(true)
+
+	existingResources := helpers.GetAllResources(cfg.fs, cfg.pathMaker.GetPathToExistingResources())
+	if len(args) == 1 {
+		if !common.Contains(existingResources, args[0]) {
+			utils.ExitIfError(sveltinerr.NewResourceNotFoundError())
+		}
+		existingResources = []string{args[0]}
+	}
+
+	cfg.log.Plain(markup.H1("Enriching the content files"))
+
+	cfg.log.Info("Getting list of all resources contents")
+	index, err := loadContentIndex(existingResources)
+	utils.ExitIfError(err)
+
+	updated := 0
+	for _, e := range index.Entries {
+		changed, err := enrichContentFile(e)
+		utils.ExitIfError(err)
+		if changed {
+			updated++
+		}
+	}
+
+	if withDryRun {
+		cfg.log.Success(fmt.Sprintf("%d of %d files to be updated\n", updated, len(index.Entries)))
+		return
+	}
+	cfg.log.Success(fmt.Sprintf("%d of %d files updated\n", updated, len(index.Entries)))
+}
+
+// enrichContentFile saves the stats to the frontmatter of the content entry and returns true when it changed.
+func enrichContentFile(e *content.Entry) (bool, error) {
+	data, err := afero.ReadFile(cfg.fs, e.Path)
+	if err != nil {
+		return false, err
+	}
+	stats := content.ComputeStats(e.Body, wordsPerMinute, tocDepth)
+	enriched, err := content.Enrich(data, stats)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", e.Path, err)
+	}
+	if bytes.Equal(enriched, data) {
+		return false, nil
+	}
+
+	cfg.log.Infof("%s (%s)", e.Path, stats)
+	if withDryRun {
+		return true, nil
+	}
+	return true, afero.WriteFile(cfg.fs, e.Path, enriched, 0644)
+}
+
+func contentEnrichCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&wordsPerMinute, "wpm", "w", content.DefaultWordsPerMinute, "Reading speed in words per minute")
+	cmd.Flags().IntVarP(&tocDepth, "toc-depth", "d", content.DefaultTOCDepth, "Deepest heading level listed in the table of contents (1-6)")
+	cmd.Flags().BoolVarP(&withDryRun, "dry-run", "", false, "List the files to be updated without saving them")
+}
+
+func init() {
+	contentCmd.AddCommand(contentEnrichCmd)
+	contentEnrichCmdFlags(contentEnrichCmd)
+}
@@ -0,0 +1,131 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/internal/content"
+	sveltinerr "github.com/sveltinio/sveltin/internal/errors"
+	"github.com/sveltinio/sveltin/internal/markup"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+var (
+	isLintStrict bool
+)
+
+//=============================================================================
+
+var contentLintCmd = &cobra.Command{
+	Use:   "lint [resource]",
+	Short: "Validate the frontmatter of the content files against the resource schemas",
+	Long: resources.GetASCIIArt() + `
+Command used to validate the frontmatter of each content file (index.svx), for all the resources
+or the given one, against the schema of its resource and to report the problems with file and line.
+
+Schemas are set by resource in sveltin.json. 'sveltin add content' creates the schema for the
+resource, if missing, with the fields set by the content templates and 'sveltin add metadata' adds
+the field for the new metadata:
+
+  "schemas": {
+    "posts": {
+      "fields": {
+        "title": { "type": "string", "required": true },
+        "slug": { "type": "string", "required": true, "unique": true },
+        "created_at": { "type": "date", "required": true, "format": "date" },
+        "category": { "type": "string", "enum": ["news", "tutorials"] },
+        "tags": { "type": "list" }
+      }
+    }
+  }
+
+Field types: any, string, number, bool, date, list, object.
+Date formats: date (YYYY-MM-DD) and datetime (RFC 3339), any date accepted by sveltin when not set.
+Enum values apply to the list items too and unique values are checked across the resource entries.
+
+Keys not listed in the schema are reported as warnings, with the closest field name when likely
+misspelled; set "allowUnknown": true for the resource to skip them. Resources with no schema are
+checked for the frontmatter syntax only.
+
+The command exits with an error when errors are found, --strict makes the warnings fail too.
+`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  RunContentLintCmd,
+}
+
+// RunContentLintCmd is the actual work function.
+func RunContentLintCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands either from a not valid directory or not latest sveltin version.
+	isValidProject(true)
+
+	existingResources := helpers.GetAllResources(cfg.fs, cfg.pathMaker.GetPathToExistingResources())
+	if len(args) == 1 {
+		if !common.Contains(existingResources, args[0]) {
+			utils.ExitIfError(sveltinerr.NewResourceNotFoundError())
+		}
+		existingResources = []string{args[0]}
+	}
+
+	cfg.log.Plain(markup.H1("Linting the content files"))
+
+	schemas, err := helpers.GetContentSchemas(cfg.fs, ProjectSettingsFile)
+	utils.ExitIfError(err)
+
+	contentMap := helpers.GetResourceContentMap(cfg.fs, existingResources, cfg.settings.GetContentPath())
+	files, errorsCount, warningsCount := 0, 0, 0
+	for _, resource := range existingResources {
+		schema, ok := schemas[resource]
+		if !ok {
+			cfg.log.Infof("No schema for %s, checking the frontmatter syntax only", resource)
+		}
+		linter := content.NewLinter(schema)
+		for _, name := range contentMap[resource] {
+			files++
+			for _, d := range lintContentFile(linter, filepath.Join(cfg.settings.GetContentPath(), resource, name, content.IndexFile)) {
+				if d.Warning {
+					warningsCount++
+					cfg.log.Warning(d.String())
+					continue
+				}
+				errorsCount++
+				cfg.log.Error(d.String())
+			}
+		}
+	}
+
+	summary := fmt.Sprintf("%d files checked, %d errors, %d warnings", files, errorsCount, warningsCount)
+	if errorsCount > 0 || (isLintStrict && warningsCount > 0) {
+		utils.ExitIfError(fmt.Errorf("%s", summary))
+	}
+	cfg.log.Success(summary + "\n")
+}
+
+// lintContentFile returns the problems found in the content file.
+func lintContentFile(linter *content.Linter, pathToFile string) []*content.Diagnostic {
+	data, err := afero.ReadFile(cfg.fs, pathToFile)
+	if err != nil {
+		return []*content.Diagnostic{{File: pathToFile, Message: fmt.Sprintf("%s not found", content.IndexFile)}}
+	}
+	return linter.Lint(pathToFile, data)
+}
+
+func contentLintCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&isLintStrict, "strict", "", false, "Exit with an error when warnings are found")
+}
+
+func init() {
+	contentCmd.AddCommand(contentLintCmd)
+	contentLintCmdFlags(contentLintCmd)
+}
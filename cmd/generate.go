@@ -9,6 +9,8 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/internal/content"
 	"github.com/sveltinio/sveltin/resources"
 )
 
@@ -17,13 +19,13 @@ import (
 var generateCmd = &cobra.Command{
 	Use:     "generate",
 	Aliases: []string{"g"},
-	Short:   "Generate static files (sitemap, rss, menu)",
+	Short:   "Generate static files (all, sitemap, rss, feed, robots, menu, search)",
 	Long: resources.GetASCIIArt() + `
 Command used to generate static files through its own subcommands.
 
 Run 'sveltin generate -h' for further details.
 `,
-	ValidArgs:             []string{"menu", "rss", "sitemap"},
+	ValidArgs:             []string{"all", "feed", "menu", "robots", "rss", "search", "sitemap"},
 	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 	DisableFlagsInUseLine: true,
 }
@@ -31,3 +33,45 @@ Run 'sveltin generate -h' for further details.
 func init() {
 	rootCmd.AddCommand(generateCmd)
 }
+
+//=============================================================================
+
+// generateSources is the data shared by the generators, read once when running more of them.
+type generateSources struct {
+	resources []string
+	index     *content.Index
+	routes    []string
+	// metadata is the map of the metadata names by resource.
+	metadata map[string][]string
+}
+
+// loadGenerateSources reads the resources, the content entries, the routes and the metadata.
+func loadGenerateSources() (*generateSources, error) {
+	cfg.log.Info("Getting list of all resources contents")
+	existingResources := helpers.GetAllResources(cfg.fs, cfg.pathMaker.GetPathToExistingResources())
+	index, err := loadContentIndex(existingResources)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.log.Info("Getting list of all routes and metadata")
+	return &generateSources{
+		resources: existingResources,
+		index:     index,
+		routes:    helpers.GetAllRoutes(cfg.fs, cfg.pathMaker.GetPathToRoutes()),
+		metadata:  helpers.GetResourceMetadataMap(cfg.fs, existingResources, cfg.pathMaker.GetPathToRoutes()),
+	}, nil
+}
+
+// loadContentIndex parses the frontmatter of the content entries for the resources.
+// Entries with a not valid frontmatter are reported and skipped.
+func loadContentIndex(resources []string) (*content.Index, error) {
+	index, err := content.Load(cfg.fs, cfg.settings.GetContentPath(), resources)
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range index.Errors {
+		cfg.log.Warning(err.Error())
+	}
+	return index, nil
+}
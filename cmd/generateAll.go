@@ -0,0 +1,252 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/helpers"
+	sveltinerr "github.com/sveltinio/sveltin/internal/errors"
+	"github.com/sveltinio/sveltin/internal/markup"
+	"github.com/sveltinio/sveltin/internal/watcher"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+var (
+	allFeedFormat string
+	withWatch     bool
+)
+
+// Generators run by 'sveltin generate all', in order.
+const (
+	menuGenerator    string = "menu"
+	feedGenerator    string = "feed"
+	sitemapGenerator string = "sitemap"
+	robotsGenerator  string = "robots"
+	searchGenerator  string = "search"
+)
+
+var allGenerators = []string{menuGenerator, feedGenerator, sitemapGenerator, robotsGenerator, searchGenerator}
+
+//=============================================================================
+
+var generateAllCmd = &cobra.Command{
+	Use:   "all",
+	Short: "Generate all the static files (menu, feeds, sitemap, robots, search) for your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to run all the generators in one pass: menu, feed, sitemap, robots and search.
+
+The content entries, the routes and the metadata are read once and shared by all the generators.
+The flags of each generator are available (e.g. --limit for the feeds, --index for the sitemap).
+
+The --format flag sets the format of the feeds (rss, atom, json or all).
+
+The --watch flag keeps watching the 'content', 'src/routes' and 'config' folders and the
+sveltin.json file and regenerates the affected files when they change:
+
+- content:    menu, feeds, sitemap and search index
+- src/routes: menu, feeds, sitemap and search index
+- config/website.js.ts: feeds
+- sveltin.json: all, after reloading the project settings
+`,
+	Args: cobra.ExactArgs(0),
+	Run:  RunGenerateAllCmd,
+}
+
+// RunGenerateAllCmd is the actual work function.
+func RunGenerateAllCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands either from a not valid directory or not latest sveltin version.
+	isValidProject(true)
+
+	formats, err := feedFormats(allFeedFormat)
+	utils.ExitIfError(err)
+
+	cfg.log.Plain(markup.H1("Generating all the static files"))
+	err = generateAll(allGenerators, formats)
+	utils.ExitIfError(err)
+	cfg.log.Success("Done\n")
+
+	if withWatch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		err = watchAndGenerate(ctx, formats)
+		utils.ExitIfError(err)
+	}
+}
+
+// generateAll runs the generators sharing the content entries, the routes and the metadata.
+func generateAll(generators []string, formats []string) error {
+	sources, err := loadGenerateSources()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range allGenerators {
+		if !common.Contains(generators, name) {
+			continue
+		}
+		switch name {
+		case menuGenerator:
+			err = generateMenu(sources)
+		case feedGenerator:
+			err = generateFeeds(sources, formats)
+		case sitemapGenerator:
+			err = generateSitemap(sources)
+		case robotsGenerator:
+			err = generateRobots()
+		case searchGenerator:
+			err = generateSearch(sources)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// watchAndGenerate regenerates the files affected by the changes within the content,
+// routes and config folders until the context is done. Errors while generating are logged.
+func watchAndGenerate(ctx context.Context, formats []string) error {
+	root := cfg.pathMaker.GetRootFolder()
+	folders := []string{
+		filepath.Join(root, cfg.pathMaker.GetContentFolder()),
+		filepath.Join(root, cfg.pathMaker.GetRoutesFolder()),
+		filepath.Join(root, cfg.pathMaker.GetConfigFolder()),
+	}
+
+	w, err := watcher.New(folders...)
+	if err != nil {
+		return err
+	}
+	if err := w.AddFile(filepath.Join(root, ProjectSettingsFile)); err != nil {
+		return err
+	}
+	w.OnError = func(err error) {
+		cfg.log.Error(err.Error())
+	}
+	cfg.log.Info("Watching the content, src/routes and config folders and sveltin.json for changes")
+
+	return w.Run(ctx, func(paths []string) {
+		generators := affectedGenerators(root, paths)
+		if len(generators) == 0 {
+			return
+		}
+		if isSettingsChanged(root, paths) {
+			if err := reloadProjectSettings(); err != nil {
+				cfg.log.Error(err.Error())
+				return
+			}
+		}
+		cfg.log.Infof("%d files changed, regenerating: %s", len(paths), strings.Join(generators, ", "))
+		if err := generateAll(generators, formats); err != nil {
+			cfg.log.Error(err.Error())
+			return
+		}
+		cfg.log.Success("Done\n")
+	})
+}
+
+// affectedGenerators returns the generators whose output depends on the changed paths.
+func affectedGenerators(root string, paths []string) []string {
+	generators := []string{}
+	add := func(names ...string) {
+		for _, name := range names {
+			if !common.Contains(generators, name) {
+				generators = append(generators, name)
+			}
+		}
+	}
+
+	contentFolder := cfg.pathMaker.GetContentFolder()
+	routesFolder := cfg.pathMaker.GetRoutesFolder()
+	configFolder := cfg.pathMaker.GetConfigFolder()
+	for _, path := range paths {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			continue
+		}
+		switch {
+		case isWithinFolder(rel, contentFolder), isWithinFolder(rel, routesFolder):
+			add(menuGenerator, feedGenerator, sitemapGenerator, searchGenerator)
+		case rel == filepath.Join(configFolder, "website.js.ts"):
+			add(feedGenerator)
+		case rel == ProjectSettingsFile:
+			// base url, sitemap, feeds and search settings.
+			add(allGenerators...)
+		}
+	}
+	return generators
+}
+
+// isSettingsChanged returns true if the project settings file is within the changed paths.
+func isSettingsChanged(root string, paths []string) bool {
+	for _, path := range paths {
+		if rel, err := filepath.Rel(root, path); err == nil && rel == ProjectSettingsFile {
+			return true
+		}
+	}
+	return false
+}
+
+// reloadProjectSettings reads the project settings file again. The current settings are kept
+// when it is not valid.
+func reloadProjectSettings() error {
+	settings, err := readProjectSettings(cfg.fs, filepath.Join(cfg.pathMaker.GetRootFolder(), ProjectSettingsFile))
+	if err != nil {
+		return fmt.Errorf("%s: %w", ProjectSettingsFile, err)
+	}
+	if err := validator.New().Struct(&settings); err != nil {
+		return sveltinerr.NewNotValidProjectSettingsError(err)
+	}
+	cfg.projectSettings = settings
+	return nil
+}
+
+// existingFeedFormats returns the formats of the feeds saved in the static folder, rss when none.
+func existingFeedFormats() []string {
+	formats := []string{}
+	for _, format := range helpers.FeedFormats {
+		pathToFile := filepath.Join(cfg.pathMaker.GetStaticFolder(), helpers.FeedFilename(format))
+		if exists, _ := common.FileExists(cfg.fs, pathToFile); exists {
+			formats = append(formats, format)
+		}
+	}
+	if len(formats) == 0 {
+		formats = append(formats, helpers.RSSFeed)
+	}
+	return formats
+}
+
+func isWithinFolder(path, folder string) bool {
+	folder = filepath.Clean(folder)
+	return path == folder || strings.HasPrefix(path, folder+string(filepath.Separator))
+}
+
+func allCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&allFeedFormat, "format", "", helpers.RSSFeed, "Format of the feeds (rss, atom, json or all)")
+	cmd.Flags().BoolVarP(&withWatch, "watch", "w", false, "Watch content, routes and config for changes and regenerate the affected files")
+}
+
+func init() {
+	generateCmd.AddCommand(generateAllCmd)
+	allCmdFlags(generateAllCmd)
+	feedCmdFlags(generateAllCmd)
+	sitemapCmdFlags(generateAllCmd)
+	robotsCmdFlags(generateAllCmd)
+	searchCmdFlags(generateAllCmd)
+}
@@ -0,0 +1,231 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/helpers/factory"
+	"github.com/sveltinio/sveltin/internal/composer"
+	"github.com/sveltinio/sveltin/internal/content"
+	sveltinerr "github.com/sveltinio/sveltin/internal/errors"
+	"github.com/sveltinio/sveltin/internal/markup"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+var (
+	feedFormat      string
+	feedLimit       int
+	withFullContent bool
+)
+
+// allFeedFormats is the --format value to generate the feed for all the formats.
+const allFeedFormats = "all"
+
+//=============================================================================
+
+var generateFeedCmd = &cobra.Command{
+	Use:   "feed",
+	Short: "Generate the feeds (RSS, Atom, JSON Feed) for your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to generate the feed files for your website into the 'static' folder:
+
+- rss:  RSS 2.0 (rss.xml)
+- atom: Atom 1.0 (atom.xml)
+- json: JSON Feed 1.1 (feed.json)
+
+All the formats are built from the same content index. Drafts are skipped and
+items are sorted by created_at, newest first. Each feed is validated for the fields
+required by its format before being saved.
+
+Set "feeds" in sveltin.json to generate a feed for each resource and for each metadata value too:
+
+  "feeds": { "resources": true, "metadata": true }
+
+The --format flag sets the format (rss, atom, json or all).
+The --limit flag sets the max number of items (0 for all).
+The --full-content flag adds the content of each entry to the items.
+`,
+	Args: cobra.ExactArgs(0),
+	Run:  RunGenerateFeedCmd,
+}
+
+// RunGenerateFeedCmd is the actual work function.
+func RunGenerateFeedCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands either from a not valid directory or not latest sveltin version.
+	isValidProject(true)
+
+	formats, err := feedFormats(feedFormat)
+	utils.ExitIfError(err)
+
+	cfg.log.Plain(markup.H1("Generating the feed files"))
+
+	sources, err := loadGenerateSources()
+	utils.ExitIfError(err)
+	err = generateFeeds(sources, formats)
+	utils.ExitIfError(err)
+
+	cfg.log.Success("Done\n")
+}
+
+// feedFormats returns the formats for the --format value.
+func feedFormats(value string) ([]string, error) {
+	if value == allFeedFormats {
+		return helpers.FeedFormats, nil
+	}
+	if !common.Contains(helpers.FeedFormats, value) {
+		return nil, sveltinerr.NewOptionNotValidError(value, []string{helpers.RSSFeed, helpers.AtomFeed, helpers.JSONFeed, allFeedFormats})
+	}
+	return []string{value}, nil
+}
+
+// generateFeeds builds the feed for each format and saves it to the static folder.
+func generateFeeds(sources *generateSources, formats []string) error {
+	existingResources, index, metadata := sources.resources, sources.index, sources.metadata
+
+	cfg.log.Info("Reading the website metadata")
+	website := loadWebSiteData()
+	// the item dates for the entries with no created_at and updated_at.
+	fileLastModified := newFileLastModified(loadGitDates())
+	lastModified := func(pathToFile string) time.Time {
+		return fileLastModified(pathToFile, false)
+	}
+
+	// GET FOLDER: static
+	staticFolder := cfg.fsManager.GetFolder(StaticFolder)
+
+	// NEW FILE: static/{rss.xml, atom.xml, feed.json}
+	cfg.log.Info("Adding the feeds for the website")
+	if err := addFeedFiles(staticFolder, formats, website, metadata, index.Entries, "", "", lastModified); err != nil {
+		return err
+	}
+
+	if cfg.projectSettings.Feeds.Resources {
+		cfg.log.Info("Adding the feeds for the resources")
+		for _, resource := range existingResources {
+			entries := index.PublishedByResource(resource)
+			if len(entries) == 0 {
+				cfg.log.Infof("Skipping the feeds for %s: no published content", resource)
+				continue
+			}
+			// NEW FILE: static/<resource_name>/{rss.xml, atom.xml, feed.json}
+			resourceFolder := composer.NewFolder(resource)
+			if err := addFeedFiles(resourceFolder, formats, website, metadata, entries, resource, utils.ToTitle(resource), lastModified); err != nil {
+				return err
+			}
+			staticFolder.Add(resourceFolder)
+		}
+	}
+
+	if cfg.projectSettings.Feeds.Metadata {
+		cfg.log.Info("Adding the feeds for the metadata")
+		for _, resource := range existingResources {
+			for _, name := range metadata[resource] {
+				// drafts are left out so that no feed is added for the values set by drafts only.
+				groups := content.GroupBy(index.PublishedByResource(resource), utils.ToSnakeCase(name))
+				for _, value := range sortedKeys(groups) {
+					if !isValidFeedFolderName(value) {
+						cfg.log.Warningf("Skipping the feed for the %s '%s' of %s: not a valid folder name", name, value, resource)
+						continue
+					}
+					// NEW FILE: static/<resource_name>/<metadata_name>/<value>/{rss.xml, atom.xml, feed.json}
+					section := path.Join(resource, name, value)
+					title := fmt.Sprintf("%s - %s: %s", utils.ToTitle(resource), utils.ToTitle(name), value)
+					valueFolder := composer.NewFolder(filepath.Join(resource, name, value))
+					if err := addFeedFiles(valueFolder, formats, website, metadata, groups[value], section, title, lastModified); err != nil {
+						return err
+					}
+					staticFolder.Add(valueFolder)
+				}
+			}
+		}
+	}
+
+	// SET FOLDER STRUCTURE
+	projectFolder := cfg.fsManager.GetFolder(RootFolder)
+	projectFolder.Add(staticFolder)
+
+	// GENERATE THE FOLDER TREE
+	sfs := factory.NewNoPageArtifact(&resources.SveltinTemplatesFS, cfg.fs)
+	return projectFolder.Create(sfs)
+}
+
+// addFeedFiles adds a feed file for each format to the folder. When set, section is the path to the page
+// the feed is about and title is added to the website title. Atom entries with no date are skipped.
+func addFeedFiles(folder *composer.Folder, formats []string, website *tpltypes.WebSiteData, metadata map[string][]string, entries []*content.Entry, section, title string, lastModified func(string) time.Time) error {
+	feedTitle := website.Title
+	if title != "" {
+		feedTitle = fmt.Sprintf("%s - %s", website.Title, title)
+	}
+	for _, format := range formats {
+		feed := helpers.NewFeedData(website, entries, &helpers.FeedOptions{
+			Path:         path.Join(section, helpers.FeedFilename(format)),
+			Section:      section,
+			Title:        feedTitle,
+			Limit:        feedLimit,
+			FullContent:  withFullContent,
+			Metadata:     metadata,
+			LastModified: lastModified,
+		})
+		if format == helpers.AtomFeed {
+			for _, link := range helpers.DropUndatedFeedItems(feed) {
+				cfg.log.Warningf("Skipping %s from the atom feed: no created_at, updated_at or file date", link)
+			}
+		}
+		if err := helpers.ValidateFeed(format, feed); err != nil {
+			return err
+		}
+		folder.Add(cfg.fsManager.NewFeedFile(format, &cfg.projectSettings, feed))
+	}
+	return nil
+}
+
+// isValidFeedFolderName returns true if the metadata value can be used as folder name.
+func isValidFeedFolderName(value string) bool {
+	return value != "." && value != ".." && !strings.ContainsAny(value, `/\`)
+}
+
+func sortedKeys(groups map[string][]*content.Entry) []string {
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// loadWebSiteData returns the website metadata, warning when config/website.js.ts cannot be read.
+func loadWebSiteData() *tpltypes.WebSiteData {
+	pathToFile := filepath.Join(cfg.pathMaker.GetConfigFolder(), WebSiteTSFile)
+	website, err := helpers.GetWebSiteData(cfg.fs, pathToFile, &cfg.projectSettings)
+	if err != nil {
+		cfg.log.Warningf("Cannot read %s, using the project settings: %s", pathToFile, err)
+	}
+	return website
+}
+
+func feedCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&feedLimit, "limit", "l", helpers.DefaultFeedLimit, "Max number of items in the feed (0 for all)")
+	cmd.Flags().BoolVarP(&withFullContent, "full-content", "c", false, "Add the content of each entry to the feed items")
+}
+
+func init() {
+	generateCmd.AddCommand(generateFeedCmd)
+	feedCmdFlags(generateFeedCmd)
+	generateFeedCmd.Flags().StringVarP(&feedFormat, "format", "f", helpers.RSSFeed, "Format of the feed (rss, atom, json or all)")
+}
@@ -28,9 +28,30 @@ var generateMenuCmd = &cobra.Command{
 	Long: resources.GetASCIIArt() + `
 Command used to generate the menu (menu.js.ts) file into the 'config' folder to be used by Svelte components.
 
-By default it list all resources and public pages.
-
-The --full flag will includes content names for all resources too.
+By default it lists the home page, the resources, the metadata and the public pages. Routes are nested
+below their parent route (e.g. posts/tags below posts) and sorted as found.
+
+Content entries with the "menu" key in the frontmatter are listed below their resource.
+The --full flag lists all the content entries, drafts excluded.
+
+The "menu" key in the frontmatter of the content entries and of the markdown public pages sets:
+
+  menu:
+    weight: 2         # items are sorted by weight
+    label: Blog       # the name of the item
+    parent: posts     # the identifier of the parent item, "/" for the top level
+    hidden: true      # the item and its children are not listed
+
+The "menu" section in sveltin.json sets the same props by identifier (the route or <resource>/<content>)
+and takes precedence. Items with an url not matching a route are added as custom (external) links:
+
+  "menu": {
+    "items": [
+      { "identifier": "posts", "label": "Blog", "weight": 2 },
+      { "identifier": "posts/tags", "hidden": true },
+      { "identifier": "github", "label": "GitHub", "url": "https://github.com/sveltinio" }
+    ]
+  }
 `,
 	Args: cobra.ExactArgs(0),
 	Run:  RunGenerateMenuCmd,
@@ -43,19 +64,34 @@ func RunGenerateMenuCmd(cmd *cobra.Command, args []string) {
 
 	cfg.log.Plain(markup.H1("Generating the menu structure file"))
 
-	cfg.log.Info("Getting list of all resources contents")
-	existingResources := helpers.GetAllResources(cfg.fs, cfg.settings.GetContentPath())
-	contents := helpers.GetResourceContentMap(cfg.fs, existingResources, cfg.settings.GetContentPath())
+	sources, err := loadGenerateSources()
+	utils.ExitIfError(err)
+	err = generateMenu(sources)
+	utils.ExitIfError(err)
 
-	cfg.log.Info("Getting list of all routes")
-	allRoutes := helpers.GetAllRoutes(cfg.fs, cfg.pathMaker.GetPathToRoutes())
+	cfg.log.Success("Done\n")
+}
+
+// generateMenu saves the menu file to the config folder.
+func generateMenu(sources *generateSources) error {
+	pages, errs := helpers.GetPagesFrontmatter(cfg.fs, cfg.pathMaker.GetPathToRoutes(), sources.routes)
+	items, menuErrs := helpers.NewMenuItems(&helpers.MenuSources{
+		Routes:      sources.routes,
+		Entries:     sources.index.Entries,
+		Pages:       pages,
+		Settings:    &cfg.projectSettings.Menu,
+		WithContent: withContentFlag,
+	})
+	for _, err := range append(errs, menuErrs...) {
+		cfg.log.Warning(err.Error())
+	}
 
 	// GET FOLDER: config
 	configFolder := cfg.fsManager.GetFolder(ConfigFolder)
 
 	// ADD FILE: config/menu.js
 	cfg.log.Info("Saving the menu.js.ts file")
-	menuFile := cfg.fsManager.NewMenuFile("menu", allRoutes, contents, withContentFlag)
+	menuFile := cfg.fsManager.NewMenuFile("menu", items)
 	configFolder.Add(menuFile)
 
 	// SET FOLDER STRUCTURE
@@ -64,10 +100,7 @@ func RunGenerateMenuCmd(cmd *cobra.Command, args []string) {
 
 	// GENERATE THE FOLDER TREE
 	sfs := factory.NewMenuArtifact(&resources.SveltinTemplatesFS, cfg.fs)
-	err := projectFolder.Create(sfs)
-	utils.ExitIfError(err)
-
-	cfg.log.Success("Done\n")
+	return projectFolder.Create(sfs)
 }
 
 func menuCmdFlags(cmd *cobra.Command) {
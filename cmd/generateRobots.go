@@ -0,0 +1,109 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/helpers/factory"
+	"github.com/sveltinio/sveltin/internal/markup"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+var (
+	robotsEnv string
+)
+
+//=============================================================================
+
+var generateRobotsCmd = &cobra.Command{
+	Use:   "robots",
+	Short: "Generate the robots.txt file for your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to generate the robots.txt file for your website into the 'static' folder.
+
+The rules are read from the "robots" section in sveltin.json. All the crawlers are allowed when no rule is set.
+
+  "robots": {
+    "rules": [
+      { "userAgents": ["*"], "allow": ["/"], "disallow": ["/drafts/"], "crawlDelay": 10 }
+    ]
+  }
+
+The absolute url to the sitemap index (sitemap-index.xml) or to the sitemap (sitemap.xml) is appended
+when the file exists in the 'static' folder. Run 'sveltin generate sitemap' first.
+
+The --env flag sets the environment the file is for. For environments other than production
+all the crawlers are disallowed (Disallow: /).
+`,
+	Args: cobra.ExactArgs(0),
+	Run:  RunGenerateRobotsCmd,
+}
+
+// RunGenerateRobotsCmd is the actual work function.
+func RunGenerateRobotsCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands either from a not valid directory or not latest sveltin version.
+	isValidProject(true)
+
+	cfg.log.Plain(markup.H1("Generating the robots.txt file"))
+
+	err := generateRobots()
+	utils.ExitIfError(err)
+
+	cfg.log.Success("Done\n")
+}
+
+// generateRobots saves the robots.txt file to the static folder.
+func generateRobots() error {
+	sitemapFile := ""
+	for _, name := range []string{"sitemap-index.xml", "sitemap.xml"} {
+		if exists, _ := common.FileExists(cfg.fs, filepath.Join(cfg.pathMaker.GetStaticFolder(), name)); exists {
+			sitemapFile = name
+			break
+		}
+	}
+	if sitemapFile == "" {
+		cfg.log.Warning("No sitemap found in the static folder, the Sitemap line is not added")
+	}
+
+	robots, err := helpers.NewRobotsTxtData(&cfg.projectSettings.Robots, cfg.projectSettings.BaseURL, sitemapFile, robotsEnv)
+	if err != nil {
+		return err
+	}
+	if robots.DisallowAll {
+		cfg.log.Infof("Disallowing all the crawlers for the %s environment", robotsEnv)
+	}
+
+	// GET FOLDER: static
+	staticFolder := cfg.fsManager.GetFolder(StaticFolder)
+
+	// NEW FILE: static/robots.txt
+	cfg.log.Info("Saving the robots.txt file to the static folder")
+	staticFolder.Add(cfg.fsManager.NewRobotsFile(&cfg.projectSettings, robots))
+
+	// SET FOLDER STRUCTURE
+	projectFolder := cfg.fsManager.GetFolder(RootFolder)
+	projectFolder.Add(staticFolder)
+
+	// GENERATE THE FOLDER TREE
+	sfs := factory.NewNoPageArtifact(&resources.SveltinTemplatesFS, cfg.fs)
+	return projectFolder.Create(sfs)
+}
+
+func robotsCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&robotsEnv, "env", "e", helpers.ProductionEnv, "Environment the robots.txt file is for (e.g. production, staging)")
+}
+
+func init() {
+	generateCmd.AddCommand(generateRobotsCmd)
+	robotsCmdFlags(generateRobotsCmd)
+}
@@ -10,7 +10,6 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"github.com/sveltinio/sveltin/helpers"
-	"github.com/sveltinio/sveltin/helpers/factory"
 	"github.com/sveltinio/sveltin/internal/markup"
 	"github.com/sveltinio/sveltin/resources"
 	"github.com/sveltinio/sveltin/utils"
@@ -22,11 +21,21 @@ var generateRssCmd = &cobra.Command{
 	Use:   "rss",
 	Short: "Generate the RSS feed for your Sveltin project",
 	Long: resources.GetASCIIArt() + `
-Command used to generate the RSS feed (rss.xml) file for your website.
+Command used to generate the RSS 2.0 feed (rss.xml) file for your website.
+
+The channel metadata are read from sveltin.json and config/website.js.ts.
+Items are sorted by created_at, newest first. Drafts are skipped.
+Values set in the frontmatter for the resource metadata are used as item categories.
+
+Set "feeds" in sveltin.json to generate a feed for each resource and for each metadata value too:
+
+  "feeds": { "resources": true, "metadata": true }
+
+The --limit flag sets the max number of items (0 for all).
+The --full-content flag adds the content of each entry to the items (content:encoded).
 `,
-	DisableFlagsInUseLine: true,
-	Args:                  cobra.ExactArgs(0),
-	Run:                   RunGenerateRSSCmd,
+	Args: cobra.ExactArgs(0),
+	Run:  RunGenerateRSSCmd,
 }
 
 // RunGenerateRSSCmd is the actual work function.
@@ -36,28 +45,9 @@ func RunGenerateRSSCmd(cmd *cobra.Command, args []string) {
 
 	cfg.log.Plain(markup.H1("Generating the RSS feed file"))
 
-	cfg.log.Info("Getting list of all resources contents")
-	existingResources := helpers.GetAllResources(cfg.fs, cfg.pathMaker.GetPathToExistingResources())
-	contents := helpers.GetResourceContentMap(cfg.fs, existingResources, cfg.settings.GetContentPath())
-
-	cfg.log.Info("Getting list of all routes")
-	allRoutes := helpers.GetAllRoutes(cfg.fs, cfg.pathMaker.GetPathToRoutes())
-
-	// GET FOLDER: static
-	staticFolder := cfg.fsManager.GetFolder(StaticFolder)
-
-	// NEW FILE: static/rss.xml
-	cfg.log.Info("Saving the file to the static folder")
-	rssFile := cfg.fsManager.NewNoPageFile("rss", &cfg.projectSettings, allRoutes, contents)
-	staticFolder.Add(rssFile)
-
-	// SET FOLDER STRUCTURE
-	projectFolder := cfg.fsManager.GetFolder(RootFolder)
-	projectFolder.Add(staticFolder)
-
-	// GENERATE THE FOLDER TREE
-	sfs := factory.NewNoPageArtifact(&resources.SveltinTemplatesFS, cfg.fs)
-	err := projectFolder.Create(sfs)
+	sources, err := loadGenerateSources()
+	utils.ExitIfError(err)
+	err = generateFeeds(sources, []string{helpers.RSSFeed})
 	utils.ExitIfError(err)
 
 	cfg.log.Success("Done\n")
@@ -65,4 +55,5 @@ func RunGenerateRSSCmd(cmd *cobra.Command, args []string) {
 
 func init() {
 	generateCmd.AddCommand(generateRssCmd)
+	feedCmdFlags(generateRssCmd)
 }
@@ -0,0 +1,133 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/helpers/factory"
+	"github.com/sveltinio/sveltin/internal/markup"
+	"github.com/sveltinio/sveltin/internal/search"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+var (
+	searchBoost   map[string]int
+	searchMaxSize int
+)
+
+//=============================================================================
+
+var generateSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Generate the client-side search index for your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to generate the search index (search.json) for your website into the 'static' folder.
+
+Titles, headlines, keywords, metadata values and the plain text of the content entries
+(markdown, html and code blocks stripped) are tokenized, English stop words are skipped and
+the words are stemmed. Drafts are skipped.
+
+The first time, the src/lib/search.ts helper is created to load and query the index from the
+theme components; it is never overwritten:
+
+  import { loadSearchIndex, search } from '$lib/search';
+
+  const index = await loadSearchIndex(fetch);
+  const results = search(index, 'getting started');
+
+The weight of each field and the size budget are set in sveltin.json:
+
+  "search": {
+    "boost": { "title": 10, "headline": 5, "keywords": 5, "metadata": 3, "body": 1 },
+    "maxSize": 512
+  }
+
+The --boost flag sets the weight of a field (e.g. --boost title=20,body=0), 0 skips the field.
+The --max-size flag sets the size budget in KB; the terms with the lowest score are dropped to fit it.
+`,
+	Args: cobra.ExactArgs(0),
+	Run:  RunGenerateSearchCmd,
+}
+
+// RunGenerateSearchCmd is the actual work function.
+func RunGenerateSearchCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands either from a not valid directory or not latest sveltin version.
+	isValidProject(true)
+
+	cfg.log.Plain(markup.H1("Generating the search index"))
+
+	sources, err := loadGenerateSources()
+	utils.ExitIfError(err)
+	err = generateSearch(sources)
+	utils.ExitIfError(err)
+
+	cfg.log.Success("Done\n")
+}
+
+// generateSearch saves the search index to the static folder and, if missing, the search helper to the lib folder.
+func generateSearch(sources *generateSources) error {
+	boost, err := helpers.SearchBoost(cfg.projectSettings.Search.Boost, searchBoost)
+	if err != nil {
+		return err
+	}
+	maxSize := cfg.projectSettings.Search.MaxSize
+	if searchMaxSize > 0 {
+		maxSize = searchMaxSize
+	}
+
+	cfg.log.Info("Indexing the content entries")
+	docs := helpers.NewSearchDocuments(sources.index.Entries, sources.metadata)
+	index := search.Build(docs, boost)
+	dropped, err := index.Prune(maxSize * 1024)
+	if err != nil {
+		return err
+	}
+	if dropped > 0 {
+		cfg.log.Warningf("%d terms with the lowest score dropped to fit the size budget (%d KB)", dropped, maxSize)
+	}
+	cfg.log.Infof("%d documents and %d terms indexed", len(index.Docs), len(index.Terms))
+
+	// GET FOLDER: static
+	staticFolder := cfg.fsManager.GetFolder(StaticFolder)
+
+	// NEW FILE: static/search.json
+	cfg.log.Info("Saving the search index to the static folder")
+	staticFolder.Add(cfg.fsManager.NewSearchIndexFile(&cfg.projectSettings, index))
+
+	// SET FOLDER STRUCTURE
+	projectFolder := cfg.fsManager.GetFolder(RootFolder)
+	projectFolder.Add(staticFolder)
+
+	// NEW FILE: src/lib/search.ts
+	pathToLibFile := filepath.Join(cfg.pathMaker.GetLibFolder(), helpers.SearchLibFilename)
+	if exists, _ := common.FileExists(cfg.fs, pathToLibFile); !exists {
+		cfg.log.Info("Saving the search helper to the lib folder")
+		libFolder := cfg.fsManager.GetFolder(LibFolder)
+		libFolder.Add(cfg.fsManager.NewSearchLibFile(&cfg.projectSettings))
+		projectFolder.Add(libFolder)
+	}
+
+	// GENERATE THE FOLDER TREE
+	sfs := factory.NewNoPageArtifact(&resources.SveltinTemplatesFS, cfg.fs)
+	return projectFolder.Create(sfs)
+}
+
+func searchCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().StringToIntVarP(&searchBoost, "boost", "b", nil, "Weight of the fields (title, headline, keywords, metadata, body), e.g. title=20,body=0")
+	cmd.Flags().IntVarP(&searchMaxSize, "max-size", "m", 0, "Size budget for the search index in KB (0 for the value in sveltin.json)")
+}
+
+func init() {
+	generateCmd.AddCommand(generateSearchCmd)
+	searchCmdFlags(generateSearchCmd)
+}
@@ -8,14 +8,26 @@
 package cmd
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/sveltinio/sveltin/helpers"
 	"github.com/sveltinio/sveltin/helpers/factory"
+	"github.com/sveltinio/sveltin/internal/content"
 	"github.com/sveltinio/sveltin/internal/markup"
 	"github.com/sveltinio/sveltin/resources"
 	"github.com/sveltinio/sveltin/utils"
 )
 
+var (
+	withSitemapIndex  bool
+	withSitemapImages bool
+)
+
 //=============================================================================
 
 var generateSitemapCmd = &cobra.Command{
@@ -23,10 +35,31 @@ var generateSitemapCmd = &cobra.Command{
 	Short: "Generate the sitemap file for your Sveltin project",
 	Long: resources.GetASCIIArt() + `
 Command used to generate the sitemap (sitemap.xml) file for your website.
+
+It lists the home page, the public pages, the resources, the content entries and
+the metadata routes (index and values). Drafts are skipped.
+
+<lastmod> is set from updated_at in the frontmatter, falling back to the time of the
+last git commit or the file modification time. changefreq and priority default to the
+values in sveltin.json and can be set for each content entry in its frontmatter:
+
+  sitemap:
+    changefreq: weekly
+    priority: 0.8
+
+Entries with translations list them as xhtml:link alternates:
+
+  lang: en
+  translations:
+    it: ciao-mondo
+
+The --index flag generates a sitemap index (sitemap-index.xml) referencing a sitemap file
+for the pages and for each resource. Files are split at the protocol limits (50,000 urls
+or 50MB); the index is generated anyway when a single sitemap would exceed them.
+The --images flag adds the cover and the static images of each content entry (image:image).
 `,
-	DisableFlagsInUseLine: true,
-	Args:                  cobra.ExactArgs(0),
-	Run:                   RunGenerateSitemapCmd,
+	Args: cobra.ExactArgs(0),
+	Run:  RunGenerateSitemapCmd,
 }
 
 // RunGenerateSitemapCmd is the actual work function.
@@ -36,20 +69,58 @@ func RunGenerateSitemapCmd(cmd *cobra.Command, args []string) {
 
 	cfg.log.Plain(markup.H1("Generating the sitemap file"))
 
-	cfg.log.Info("Getting list of all resources contents")
-	existingResources := helpers.GetAllResources(cfg.fs, cfg.settings.GetContentPath())
-	contents := helpers.GetResourceContentMap(cfg.fs, existingResources, cfg.settings.GetContentPath())
+	sources, err := loadGenerateSources()
+	utils.ExitIfError(err)
+	err = generateSitemap(sources)
+	utils.ExitIfError(err)
+
+	cfg.log.Success("Done\n")
+}
 
-	cfg.log.Info("Getting list of all routes")
-	allRoutes := helpers.GetAllRoutes(cfg.fs, cfg.pathMaker.GetPathToRoutes())
+// generateSitemap saves the sitemap, or the sitemap index and the sitemap files, to the static folder.
+func generateSitemap(sources *generateSources) error {
+	sitemapSources := &helpers.SitemapSources{
+		BaseURL:      cfg.projectSettings.BaseURL,
+		Settings:     cfg.projectSettings.Sitemap,
+		Routes:       sources.routes,
+		Resources:    sources.resources,
+		Metadata:     sources.metadata,
+		Entries:      sources.index.Entries,
+		Language:     loadWebSiteData().Language,
+		LastModified: newFileLastModified(loadGitDates()),
+	}
+	if withSitemapImages {
+		sitemapSources.Images = func(e *content.Entry) []string {
+			return helpers.GetContentImages(cfg.fs, cfg.pathMaker.GetStaticFolder(), cfg.projectSettings.BaseURL, e)
+		}
+	}
+	urls, errs := helpers.NewSitemapURLs(sitemapSources)
+	for _, err := range errs {
+		cfg.log.Warning(err.Error())
+	}
 
 	// GET FOLDER: static
 	staticFolder := cfg.fsManager.GetFolder(StaticFolder)
 
-	// NEW FILE: static/rss.xml
-	cfg.log.Info("Saving the file to the static folder")
-	sitemapFile := cfg.fsManager.NewNoPageFile("sitemap", &cfg.projectSettings, allRoutes, contents)
-	staticFolder.Add(sitemapFile)
+	sitemaps := helpers.SplitSitemap(cfg.projectSettings.BaseURL, urls, helpers.SitemapMaxURLs, helpers.SitemapMaxBytes)
+	withIndex := withSitemapIndex
+	if !withIndex && helpers.SitemapExceedsLimits(urls, helpers.SitemapMaxURLs, helpers.SitemapMaxBytes) {
+		cfg.log.Warning("The sitemap exceeds the protocol limits, generating a sitemap index")
+		withIndex = true
+	}
+
+	cfg.log.Info("Saving the sitemap files to the static folder")
+	if withIndex {
+		// NEW FILE: static/sitemap-index.xml
+		staticFolder.Add(cfg.fsManager.NewSitemapIndexFile("sitemap-index", &cfg.projectSettings, sitemaps))
+		// NEW FILE: static/sitemap-<group>.xml
+		for _, sitemap := range sitemaps {
+			staticFolder.Add(cfg.fsManager.NewSitemapFile(sitemap.Name, &cfg.projectSettings, sitemap.URLs))
+		}
+	} else {
+		// NEW FILE: static/sitemap.xml
+		staticFolder.Add(cfg.fsManager.NewSitemapFile("sitemap", &cfg.projectSettings, urls))
+	}
 
 	// SET FOLDER STRUCTURE
 	projectFolder := cfg.fsManager.GetFolder(RootFolder)
@@ -57,12 +128,65 @@ func RunGenerateSitemapCmd(cmd *cobra.Command, args []string) {
 
 	// GENERATE THE FOLDER TREE
 	sfs := factory.NewNoPageArtifact(&resources.SveltinTemplatesFS, cfg.fs)
-	err := projectFolder.Create(sfs)
-	utils.ExitIfError(err)
+	return projectFolder.Create(sfs)
+}
 
-	cfg.log.Success("Done\n")
+// loadGitDates returns the time of the last commit for the project files, read with a single
+// git log pass. It is empty when git is not available or the project is not tracked.
+func loadGitDates() helpers.GitDates {
+	out, err := exec.Command("git", "-c", "core.quotePath=false", "log", "--name-only", "--format=%cI", "--relative").Output()
+	if err != nil {
+		return helpers.GitDates{}
+	}
+	return helpers.ParseGitDates(out)
+}
+
+// newFileLastModified returns the function returning the time of the last git commit for the
+// content file or route folder, the modification time on the file system when it is not tracked by git.
+func newFileLastModified(gitDates helpers.GitDates) func(pathToFile string, isRoute bool) time.Time {
+	cwd, _ := os.Getwd()
+	return func(pathToFile string, isRoute bool) time.Time {
+		if isRoute {
+			pathToFile = filepath.Join(cfg.pathMaker.GetPathToRoutes(), pathToFile)
+		}
+		relPath := pathToFile
+		if filepath.IsAbs(pathToFile) {
+			if rel, err := filepath.Rel(cwd, pathToFile); err == nil {
+				relPath = rel
+			}
+		}
+		if t, ok := gitDates.LastCommit(relPath); ok {
+			return t
+		}
+		return fsLastModified(pathToFile)
+	}
+}
+
+// fsLastModified returns the modification time of the file or, for a folder, the most recent of its files.
+func fsLastModified(pathToFile string) time.Time {
+	info, err := cfg.fs.Stat(pathToFile)
+	if err != nil {
+		return time.Time{}
+	}
+	if !info.IsDir() {
+		return info.ModTime()
+	}
+	var lastMod time.Time
+	files, _ := afero.ReadDir(cfg.fs, pathToFile)
+	for _, f := range files {
+		if !f.IsDir() && f.ModTime().After(lastMod) {
+			lastMod = f.ModTime()
+		}
+	}
+	return lastMod
+}
+
+func sitemapCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&withSitemapIndex, "index", "i", false, "Generate a sitemap index with a sitemap file for the pages and each resource")
+	cmd.Flags().BoolVarP(&withSitemapImages, "images", "", false, "Add the cover and the static images of the content entries")
 }
 
 func init() {
 	generateCmd.AddCommand(generateSitemapCmd)
+	sitemapCmdFlags(generateSitemapCmd)
 }
@@ -0,0 +1,122 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/helpers"
+	sveltinerr "github.com/sveltinio/sveltin/internal/errors"
+	"github.com/sveltinio/sveltin/internal/markup"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+var (
+	listOutput     string
+	listSortBy     string
+	isListSortDesc bool
+	// listResourceName is the --resource filter shared by the list subcommands supporting it.
+	listResourceName string
+)
+
+//=============================================================================
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List resources, content, metadata, pages and routes of your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to inspect the structure of your project through its own subcommands.
+
+The output is a table by default, --output json and --output csv print the same rows
+for scripts; column names are the JSON keys and the values for --sort.
+
+Run 'sveltin list -h' for further details.
+`,
+	ValidArgs:             []string{"content", "metadata", "pages", "resources", "routes"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+}
+
+func listCmdFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&listOutput, "output", "o", helpers.TableOutput, "Output format (table, json, csv)")
+	err := cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return helpers.ListOutputs, cobra.ShellCompDirectiveNoFileComp
+	})
+	utils.ExitIfError(err)
+	cmd.PersistentFlags().StringVarP(&listSortBy, "sort", "s", "", "Column to sort the rows by")
+	cmd.PersistentFlags().BoolVarP(&isListSortDesc, "desc", "", false, "Sort the rows in descending order")
+}
+
+func init() {
+	listCmdFlags(listCmd)
+	rootCmd.AddCommand(listCmd)
+}
+
+//=============================================================================
+
+// validateListOutput exits if the output format is not valid, before reading the project.
+func validateListOutput() {
+	if !common.Contains(helpers.ListOutputs, listOutput) {
+		utils.ExitIfError(fmt.Errorf("%q is not a valid output format, valid formats are: %s", listOutput, strings.Join(helpers.ListOutputs, ", ")))
+	}
+}
+
+// listResources returns the existing resources, or the one set with --resource if it exists.
+func listResources() []string {
+	existingResources := helpers.GetAllResources(cfg.fs, cfg.pathMaker.GetPathToExistingResources())
+	if listResourceName == "" {
+		return existingResources
+	}
+	if !common.Contains(existingResources, listResourceName) {
+		utils.ExitIfError(sveltinerr.NewResourceNotFoundError())
+	}
+	return []string{listResourceName}
+}
+
+// listWarnings reports the errors to the log for tables and to stderr otherwise, so that
+// the json and csv outputs can be piped.
+func listWarnings(errs []error) {
+	for _, err := range errs {
+		if listOutput == helpers.TableOutput {
+			cfg.log.Warning(err.Error())
+			continue
+		}
+		fmt.Fprintln(os.Stderr, err.Error())
+	}
+}
+
+// printList sorts the rows as set by the flags and prints them.
+func printList(title string, table *helpers.ListTable) {
+	if listSortBy != "" {
+		utils.ExitIfError(table.Sort(listSortBy, isListSortDesc))
+	}
+	if listOutput == helpers.TableOutput {
+		cfg.log.Plain(markup.H1(title))
+		if len(table.Rows) == 0 {
+			cfg.log.Info("Nothing found")
+			return
+		}
+	}
+	utils.ExitIfError(table.Write(os.Stdout, listOutput))
+}
+
+// listResourceFlag adds the --resource flag to the list subcommand.
+func listResourceFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&listResourceName, "resource", "r", "", "Name of the resource to list")
+	err := cmd.RegisterFlagCompletionFunc("resource", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		availableResources := helpers.GetAllResources(cfg.fs, cfg.settings.GetContentPath())
+		return availableResources, cobra.ShellCompDirectiveDefault
+	})
+	utils.ExitIfError(err)
+}
@@ -0,0 +1,96 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+var (
+	isListDraft bool
+	listTag     string
+)
+
+//=============================================================================
+
+var listContentCmd = &cobra.Command{
+	Use:   "content",
+	Short: "List the content entries of your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to list the content entries with the title, slug, draft status and dates from their frontmatter.
+
+Columns: resource, slug, title, draft, created, updated.
+
+The --resource flag lists the entries for the resource only.
+The --draft flag lists the drafts only, --draft=false the published entries only.
+The --tag flag lists the entries with the value among their keywords or metadata (e.g. --tag svelte).
+
+Examples:
+
+  sveltin list content --resource posts --draft=false --sort created --desc
+  sveltin list content --tag svelte --output csv > svelte.csv
+`,
+	Args: cobra.ExactArgs(0),
+	Run:  RunListContentCmd,
+}
+
+// RunListContentCmd is the actual work function.
+func RunListContentCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands from a not valid directory.
+	isValidProject(false)
+	validateListOutput()
+
+	existingResources := listResources()
+	index, err := content.Load(cfg.fs, cfg.settings.GetContentPath(), existingResources)
+	utils.ExitIfError(err)
+	listWarnings(index.Errors)
+
+	var draft *bool
+	if cmd.Flags().Changed("draft") {
+		draft = &isListDraft
+	}
+	metadataMap := helpers.GetResourceMetadataMap(cfg.fs, existingResources, cfg.pathMaker.GetPathToRoutes())
+	entries := helpers.FilterContentEntries(index.Entries, metadataMap, listResourceName, draft, listTag)
+
+	table := helpers.NewListTable("resource", "slug", "title", "draft", "created", "updated")
+	for _, e := range entries {
+		title := e.Title
+		if title == "" {
+			title = utils.ToTitle(e.Name)
+		}
+		table.Append(e.Resource, e.PageSlug(), title, e.Draft, formatListDate(e.CreatedAt), formatListDate(e.UpdatedAt))
+	}
+	printList("Content", table)
+}
+
+func listContentCmdFlags(cmd *cobra.Command) {
+	listResourceFlag(cmd)
+	cmd.Flags().BoolVarP(&isListDraft, "draft", "d", false, "List the drafts only, --draft=false for the published entries only")
+	cmd.Flags().StringVarP(&listTag, "tag", "t", "", "List the entries with the value among their keywords or metadata")
+}
+
+func init() {
+	listContentCmdFlags(listContentCmd)
+	listCmd.AddCommand(listContentCmd)
+}
+
+//=============================================================================
+
+// formatListDate returns the date as YYYY-MM-DD, empty if not set.
+func formatListDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
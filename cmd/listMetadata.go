@@ -0,0 +1,67 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+//=============================================================================
+
+var listMetadataCmd = &cobra.Command{
+	Use:   "metadata",
+	Short: "List the metadata of your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to list the metadata for each resource with the values set in the frontmatter of the content entries.
+
+Columns: resource, metadata, count, values.
+
+The --resource flag lists the metadata for the resource only.
+`,
+	Args: cobra.ExactArgs(0),
+	Run:  RunListMetadataCmd,
+}
+
+// RunListMetadataCmd is the actual work function.
+func RunListMetadataCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands from a not valid directory.
+	isValidProject(false)
+	validateListOutput()
+
+	existingResources := listResources()
+	index, err := content.Load(cfg.fs, cfg.settings.GetContentPath(), existingResources)
+	utils.ExitIfError(err)
+	listWarnings(index.Errors)
+	metadataMap := helpers.GetResourceMetadataMap(cfg.fs, existingResources, cfg.pathMaker.GetPathToRoutes())
+
+	table := helpers.NewListTable("resource", "metadata", "count", "values")
+	for _, resource := range existingResources {
+		entries := helpers.FilterContentEntries(index.Entries, metadataMap, resource, nil, "")
+		for _, name := range metadataMap[resource] {
+			values := []string{}
+			for value := range content.GroupBy(entries, utils.ToSnakeCase(name)) {
+				values = append(values, value)
+			}
+			sort.Strings(values)
+			table.Append(resource, name, len(values), strings.Join(values, ", "))
+		}
+	}
+	printList("Metadata", table)
+}
+
+func init() {
+	listResourceFlag(listMetadataCmd)
+	listCmd.AddCommand(listMetadataCmd)
+}
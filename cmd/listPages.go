@@ -0,0 +1,55 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/resources"
+)
+
+//=============================================================================
+
+var listPagesCmd = &cobra.Command{
+	Use:   "pages",
+	Short: "List the public pages of your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to list the public pages, as created by 'sveltin new page', with their type and title.
+The title is the one in the frontmatter for markdown pages.
+
+Columns: route, type, title.
+`,
+	Args: cobra.ExactArgs(0),
+	Run:  RunListPagesCmd,
+}
+
+// RunListPagesCmd is the actual work function.
+func RunListPagesCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands from a not valid directory.
+	isValidProject(false)
+	validateListOutput()
+
+	existingResources := helpers.GetAllResources(cfg.fs, cfg.pathMaker.GetPathToExistingResources())
+	routes := helpers.GetAllRoutes(cfg.fs, cfg.pathMaker.GetPathToRoutes())
+	metadataMap := helpers.GetResourceMetadataMap(cfg.fs, existingResources, cfg.pathMaker.GetPathToRoutes())
+	pages := helpers.GetPublicPages(cfg.fs, cfg.pathMaker.GetPathToRoutes(), routes, existingResources, metadataMap)
+	frontmatters, errs := helpers.GetPagesFrontmatter(cfg.fs, cfg.pathMaker.GetPathToRoutes(), routes)
+	listWarnings(errs)
+
+	table := helpers.NewListTable("route", "type", "title")
+	for _, route := range routes {
+		if pageType, ok := pages[route]; ok {
+			table.Append("/"+route, pageType, helpers.PageTitle(route, frontmatters[route]))
+		}
+	}
+	printList("Pages", table)
+}
+
+func init() {
+	listCmd.AddCommand(listPagesCmd)
+}
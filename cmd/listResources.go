@@ -0,0 +1,51 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/resources"
+)
+
+//=============================================================================
+
+var listResourcesCmd = &cobra.Command{
+	Use:   "resources",
+	Short: "List the resources of your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to list the resources with the number of their content entries and their metadata.
+
+Columns: resource, content, metadata.
+`,
+	Args: cobra.ExactArgs(0),
+	Run:  RunListResourcesCmd,
+}
+
+// RunListResourcesCmd is the actual work function.
+func RunListResourcesCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands from a not valid directory.
+	isValidProject(false)
+	validateListOutput()
+
+	existingResources := helpers.GetAllResources(cfg.fs, cfg.pathMaker.GetPathToExistingResources())
+	contentMap := helpers.GetResourceContentMap(cfg.fs, existingResources, cfg.settings.GetContentPath())
+	metadataMap := helpers.GetResourceMetadataMap(cfg.fs, existingResources, cfg.pathMaker.GetPathToRoutes())
+
+	table := helpers.NewListTable("resource", "content", "metadata")
+	for _, resource := range existingResources {
+		table.Append(resource, len(contentMap[resource]), strings.Join(metadataMap[resource], ", "))
+	}
+	printList("Resources", table)
+}
+
+func init() {
+	listCmd.AddCommand(listResourcesCmd)
+}
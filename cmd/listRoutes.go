@@ -0,0 +1,50 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/helpers"
+	"github.com/sveltinio/sveltin/resources"
+)
+
+//=============================================================================
+
+var listRoutesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "List the routes of your Sveltin project",
+	Long: resources.GetASCIIArt() + `
+Command used to list the routes within the src/routes folder with their kind:
+home, resource, metadata, page or dynamic.
+
+Columns: route, kind.
+`,
+	Args: cobra.ExactArgs(0),
+	Run:  RunListRoutesCmd,
+}
+
+// RunListRoutesCmd is the actual work function.
+func RunListRoutesCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands from a not valid directory.
+	isValidProject(false)
+	validateListOutput()
+
+	existingResources := helpers.GetAllResources(cfg.fs, cfg.pathMaker.GetPathToExistingResources())
+	routes := helpers.GetAllRoutes(cfg.fs, cfg.pathMaker.GetPathToRoutes())
+	metadataMap := helpers.GetResourceMetadataMap(cfg.fs, existingResources, cfg.pathMaker.GetPathToRoutes())
+
+	table := helpers.NewListTable("route", "kind")
+	for _, route := range routes {
+		table.Append("/"+route, helpers.GetRouteKind(route, existingResources, metadataMap))
+	}
+	printList("Routes", table)
+}
+
+func init() {
+	listCmd.AddCommand(listRoutesCmd)
+}
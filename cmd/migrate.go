@@ -8,20 +8,32 @@ package cmd
  */
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
-	"sort"
+	"path/filepath"
+	"strings"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/sveltinio/prompti/confirm"
+	"github.com/sveltinio/sveltin/internal/diff"
 	"github.com/sveltinio/sveltin/internal/markup"
 	"github.com/sveltinio/sveltin/internal/migrations"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
 	"github.com/sveltinio/sveltin/resources"
 	"github.com/sveltinio/sveltin/tui/feedbacks"
 	"github.com/sveltinio/sveltin/utils"
 )
 
+var (
+	isMigrateDryRun       bool
+	isMigrateYes          bool
+	withMigrationRules    string
+	withMigrationProjects string
+)
+
 //=============================================================================
 
 var migrateCmd = &cobra.Command{
@@ -29,6 +41,43 @@ var migrateCmd = &cobra.Command{
 	Short: "Migrate your project to the latest Sveltin version",
 	Long: resources.GetASCIIArt() + `
 Command used to migrate your project files to the latest Sveltin version.
+
+Files are saved to 'backups/migrations/<timestamp>' before being changed and automatically
+restored if a migration fails. Run 'sveltin migrate rollback' to restore them on demand.
+
+The --dry-run flag prints the changes as unified diffs without writing them.
+It exits with a non-zero status code when there are pending changes.
+
+A migration-report.json file and its markdown summary (migration-report.md) are saved
+to the project folder after migrating.
+
+The --projects flag migrates many projects at once. Its value is a glob (e.g. 'sites/*')
+or a file listing the project folders, one per line. Failures do not stop the other
+projects and a summary table is printed at the end. Use --yes to skip the confirmation.
+
+The --rules flag runs user-defined migrations from a YAML file, e.g.
+
+  migrations:
+    - name: theme-header
+      target: themes/**/*.svelte
+      gatekeeper: NewHeader
+      rules:
+        - trigger: import Header from '(.*)';
+          replace: import NewHeader from '$1';
+          replaceFullLine: true
+
+and to migrate the frontmatter of the content files (content/<resource>/<slug>/index.svx):
+
+  frontmatter:
+    - name: posts-author
+      resources: [posts]
+      rules:
+        - action: move      # rename | move | default | delete
+          key: author
+          to: misc.author
+        - action: default
+          key: draft
+          value: false
 `,
 	DisableFlagsInUseLine: true,
 	Args:                  cobra.ExactArgs(0),
@@ -37,90 +86,210 @@ Command used to migrate your project files to the latest Sveltin version.
 
 // RunMigrateCmd is the actual work function.
 func RunMigrateCmd(cmd *cobra.Command, args []string) {
+	if len(withMigrationProjects) != 0 {
+		runBatchMigration()
+		return
+	}
+
 	// Exit if running sveltin commands from a not valid directory.
 	isValidProject(false)
 
+	if isMigrateDryRun {
+		cfg.log.Plain(markup.H1(fmt.Sprintf("Previewing the migration of your project to sveltin v%s", CliVersion)))
+		migrationServices, _, err := migrateProject(true)
+		utils.ExitIfError(err)
+
+		changes := migrationServices.GetChanges()
+		if len(changes) == 0 {
+			cfg.log.Success(markup.Green(fmt.Sprintf("Your project is already migrated to sveltin v%s\n", CliVersion)))
+			return
+		}
+		showMigrationChanges(changes)
+		cfg.log.Important(fmt.Sprintf("%d file(s) need to be migrated. Run: sveltin migrate", len(changes)))
+		os.Exit(1)
+	}
+
 	feedbacks.ShowUpgradeCommandMessage()
 
-	isConfirm, err := confirm.Run(&confirm.Config{Question: "Continue?"})
-	utils.ExitIfError(err)
+	isConfirm := isMigrateYes
+	if !isConfirm {
+		var err error
+		isConfirm, err = confirm.Run(&confirm.Config{Question: "Continue?"})
+		utils.ExitIfError(err)
+	}
 
 	if isConfirm {
-		cwd, _ := os.Getwd()
 		cfg.log.Plain(markup.H1(fmt.Sprintf("Migrating your project to sveltin v%s", CliVersion)))
 
-		migrationManager := migrations.NewMigrationManager()
-		migrationServices := migrations.NewMigrationServices(cfg.fs, cfg.fsManager, cfg.pathMaker, cfg.log)
+		_, snapshot, err := migrateProject(false)
+		utils.ExitIfError(err)
 
-		/** FILE: <project_root>/sveltin.json */
-		pathToFile := path.Join(cwd, ProjectSettingsFile)
-		migrationData := &migrations.MigrationData{
-			TargetPath:        pathToFile,
-			CliVersion:        CliVersion,
-			ProjectCliVersion: cfg.projectSettings.Sveltin.Version,
+		cfg.log.Info(fmt.Sprintf("Migration report saved to %s and %s", migrations.ReportJSONFile, migrations.ReportMarkdownFile))
+		if !snapshot.IsEmpty() {
+			cfg.log.Info(fmt.Sprintf("Original files saved to %s. Run: sveltin migrate rollback to restore them", snapshot.GetFolder()))
 		}
-		migrationFactory, err := migrations.GetMigrationFactory(migrations.ProjectSettings)
-		utils.ExitIfError(err)
-		migration := migrationFactory.MakeMigration(migrationManager, migrationServices, migrationData)
-		// execute the migration.
-		err = migration.Migrate()
-		utils.ExitIfError(err)
+		cfg.log.Success(markup.Green(fmt.Sprintf("Your project is ready for sveltin v%s\n", CliVersion)))
+	}
+}
 
-		// Load project settings file after sveltin.json file creation
-		cfg.projectSettings, err = loadProjectSettings(ProjectSettingsFile)
-		utils.ExitIfError(err)
+// migrateProject runs the migrations for the project in the current directory and writes the report.
+// Files are saved to a snapshot before being changed and restored if a migration fails.
+// When dryRun is true, the changes are kept in memory and no snapshot is taken.
+func migrateProject(dryRun bool) (*migrations.MigrationServices, *migrations.Snapshot, error) {
+	cwd, _ := os.Getwd()
+	// settings are read for each project when migrating many of them.
+	projectSettings, err := readProjectSettings(cfg.fs, filepath.Join(cwd, ProjectSettingsFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+	cfg.projectSettings = projectSettings
+	fromVersion := cfg.projectSettings.Sveltin.Version
 
-		migrationIdPathToTargetMap := map[migrations.Migration]string{
-			migrations.DefaultsConfig:     path.Join(cwd, cfg.pathMaker.GetConfigFolder(), DefaultsConfigFile),
-			migrations.WebSiteTS:          path.Join(cwd, cfg.pathMaker.GetConfigFolder(), WebSiteTSFile),
-			migrations.MenuTS:             path.Join(cwd, cfg.pathMaker.GetConfigFolder(), MenuTSFile),
-			migrations.SveltinDTS:         path.Join(cwd, cfg.pathMaker.GetSrcFolder(), SveltinDTSFile),
-			migrations.ResourceLibs:       path.Join(cwd, cfg.pathMaker.GetLibFolder()),
-			migrations.Layout:             path.Join(cwd, cfg.pathMaker.GetRoutesFolder(), LayoutTSFile),
-			migrations.SvelteFiles:        path.Join(cwd, cfg.pathMaker.GetRoutesFolder()),
-			migrations.PageServerTS:       path.Join(cwd, cfg.pathMaker.GetRoutesFolder()),
-			migrations.SveltinioComponent: path.Join(cwd, cfg.pathMaker.GetRoutesFolder()),
-			migrations.ThemeConfig: path.Join(cwd, cfg.pathMaker.GetThemesFolder(),
-				cfg.projectSettings.Theme.Name, cfg.settings.GetThemeConfigFilename()),
-			migrations.ThemeSveltinioComponents: path.Join(cwd, cfg.pathMaker.GetThemesFolder()),
-			migrations.MDsveXConfig:             path.Join(cwd, MDsveXFile),
-			migrations.SvelteConfig:             path.Join(cwd, SvelteConfigFile),
-			migrations.DotEnv:                   path.Join(cwd, DotEnvProdFile),
-			migrations.ViteConfig:               path.Join(cwd, ViteConfigFile),
-			migrations.TSConfig:                 path.Join(cwd, TSConfigFile),
-			migrations.PackageJSON:              path.Join(cwd, PackageJSONFile),
-		}
+	if dryRun {
+		migrationServices := migrations.NewDryRunMigrationServices(cfg.fs, cfg.fsManager, cfg.pathMaker, cfg.log)
+		return migrationServices, nil, runMigrations(migrationServices)
+	}
 
-		// Ensure the migrations execution order
-		migrationKeys := sortedMigrationMap(migrationIdPathToTargetMap)
+	migrationServices := migrations.NewMigrationServices(cfg.fs, cfg.fsManager, cfg.pathMaker, cfg.log)
+	// backup files before changing them.
+	snapshot := migrations.NewSnapshot(cfg.fs, cfg.pathMaker.GetRootFolder(), getMigrationSnapshotsFolder())
+	migrationServices.SetSnapshot(snapshot)
 
-		for _, k := range migrationKeys {
-			_id := migrations.Migration(k)
-			_pathToFile := migrationIdPathToTargetMap[_id]
-			migrationData := &migrations.MigrationData{
-				TargetPath: _pathToFile,
+	if err := runMigrations(migrationServices); err != nil {
+		if !snapshot.IsEmpty() {
+			cfg.log.Important("Something went wrong. Restoring your project files")
+			if restoreErr := snapshot.Restore(); restoreErr != nil {
+				return migrationServices, snapshot, fmt.Errorf("%w (restore failed: %s)", err, restoreErr)
 			}
-			migrationFactory, err := migrations.GetMigrationFactory(_id)
-			utils.ExitIfError(err)
-			migration := migrationFactory.MakeMigration(migrationManager, migrationServices, migrationData)
-			// execute the migration.
-			err = migration.Migrate()
-			utils.ExitIfError(err)
 		}
+		return migrationServices, snapshot, err
+	}
 
-		cfg.log.Success(markup.Green(fmt.Sprintf("Your project is ready for sveltin v%s\n", CliVersion)))
+	report := migrationServices.BuildReport(fromVersion, CliVersion)
+	if err := migrations.WriteReport(cfg.fs, cfg.pathMaker.GetRootFolder(), report); err != nil {
+		return migrationServices, snapshot, err
 	}
+	return migrationServices, snapshot, nil
+}
+
+func migrateCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&isMigrateDryRun, "dry-run", "", false, "Print the changes as unified diffs without writing them")
+	cmd.Flags().StringVarP(&withMigrationRules, "rules", "r", "", "Path to the YAML file with user-defined migrations")
+	cmd.Flags().BoolVarP(&isMigrateYes, "yes", "y", false, "Migrate without asking for confirmation")
+	cmd.Flags().StringVarP(&withMigrationProjects, "projects", "", "", "Glob or file listing the project folders to migrate")
 }
 
 func init() {
+	migrateCmdFlags(migrateCmd)
 	rootCmd.AddCommand(migrateCmd)
 }
 
-func sortedMigrationMap(m map[migrations.Migration]string) []int {
-	keys := make([]int, 0)
-	for k := range m {
-		keys = append(keys, int(k))
+//=============================================================================
+
+func runMigrations(migrationServices *migrations.MigrationServices) error {
+	cwd, _ := os.Getwd()
+	migrationManager := migrations.NewMigrationManager()
+	// the sveltin version the project has been created/migrated with. Empty when there is no sveltin.json file.
+	projectCliVersion := cfg.projectSettings.Sveltin.Version
+
+	/** FILE: <project_root>/sveltin.json */
+	pathToFile := path.Join(cwd, ProjectSettingsFile)
+	migrationIds, err := migrations.RunChain(migrationManager, migrationServices, pathToFile, projectCliVersion, CliVersion,
+		func() (map[migrations.Migration]string, error) {
+			var err error
+			// Load project settings file after sveltin.json file creation.
+			// When previewing, the sveltin.json file could exist in memory only.
+			cfg.projectSettings, err = readProjectSettings(migrationServices.GetFS(), pathToFile)
+			if err != nil {
+				return nil, err
+			}
+			return getMigrationTargetsMap(cwd), nil
+		})
+	if err != nil {
+		return err
+	}
+
+	// Run the user-defined migrations.
+	if len(withMigrationRules) != 0 {
+		userMigrations, err := migrations.LoadUserMigrations(cfg.fs, withMigrationRules)
+		if err != nil {
+			return err
+		}
+		for _, um := range userMigrations.Migrations {
+			migrationData := &migrations.MigrationData{
+				TargetPath: cwd,
+			}
+			migration := migrations.NewUserMigration(migrationManager, migrationServices, migrationData, um)
+			if err := migrationServices.Run(migration); err != nil {
+				return err
+			}
+		}
+		for _, fm := range userMigrations.Frontmatter {
+			migrationData := &migrations.MigrationData{
+				TargetPath: path.Join(cwd, cfg.pathMaker.GetContentFolder()),
+			}
+			migration := migrations.NewFrontmatterMigration(migrationManager, migrationServices, migrationData, fm)
+			if err := migrationServices.Run(migration); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Keep track of the applied migrations.
+	return migrations.RecordAppliedMigrations(migrationServices, pathToFile, migrationIds)
+}
+
+func getMigrationSnapshotsFolder() string {
+	return filepath.Join(cfg.pathMaker.GetRootFolder(), BackupsFolder, "migrations")
+}
+
+// getMigrationTargetsMap returns the path to the file or folder each migration works on.
+func getMigrationTargetsMap(cwd string) map[migrations.Migration]string {
+	return migrations.TargetsMap(cwd, cfg.pathMaker, cfg.projectSettings.Theme.Name, cfg.settings.GetThemeConfigFilename())
+}
+
+func readProjectSettings(fs afero.Fs, pathToFile string) (prjConfig tpltypes.ProjectSettings, err error) {
+	content, err := afero.ReadFile(fs, pathToFile)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(content, &prjConfig)
+	return
+}
+
+func showMigrationChanges(changes []*migrations.FileChange) {
+	cwd, _ := os.Getwd()
+	names, groups := migrations.GroupChangesByMigration(changes)
+	for _, name := range names {
+		cfg.log.Plain(markup.H2(name))
+		for _, change := range groups[name] {
+			localFilePath, err := filepath.Rel(cwd, change.Path)
+			if err != nil {
+				localFilePath = change.Path
+			}
+			fromFile := "a/" + localFilePath
+			if change.IsNew {
+				fromFile = "/dev/null"
+			}
+			unified := diff.Unified(fromFile, "b/"+localFilePath, change.Before, change.After, 3)
+			fmt.Println(colorizeUnifiedDiff(unified))
+		}
+	}
+}
+
+func colorizeUnifiedDiff(unified string) string {
+	lines := strings.Split(strings.TrimSuffix(unified, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			lines[i] = markup.Bold(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = markup.Blue(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = markup.Green(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = markup.Red(line)
+		}
 	}
-	sort.Ints(keys)
-	return keys
+	return strings.Join(lines, "\n")
 }
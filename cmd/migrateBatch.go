@@ -0,0 +1,238 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/afero"
+	"github.com/sveltinio/prompti/confirm"
+	"github.com/sveltinio/sveltin/internal/markup"
+	"github.com/sveltinio/sveltin/tui/feedbacks"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+// Batch migration statuses.
+const (
+	projectMigrated = "migrated"
+	projectUpToDate = "up-to-date"
+	projectPending  = "pending"
+	projectFailed   = "failed"
+	projectNotValid = "not a project"
+)
+
+const (
+	unknownSveltinVersion = "unknown"
+	// errorColumnWidth is the max length of the error messages in the summary table.
+	errorColumnWidth = 60
+)
+
+// projectMigrationResult is the struct representing the outcome of the migration of a single project.
+type projectMigrationResult struct {
+	root     string
+	from     string
+	status   string
+	changes  int
+	warnings int
+	err      error
+}
+
+func runBatchMigration() {
+	roots, err := resolveMigrationProjects(cfg.fs, withMigrationProjects)
+	utils.ExitIfError(err)
+	if len(roots) == 0 {
+		utils.ExitIfError(fmt.Errorf("no project folders found for %s", withMigrationProjects))
+	}
+
+	if !isMigrateDryRun && !isMigrateYes {
+		feedbacks.ShowUpgradeCommandMessage()
+		cfg.log.Plain(fmt.Sprintf("%d project(s) will be migrated to sveltin v%s", len(roots), CliVersion))
+		isConfirm, err := confirm.Run(&confirm.Config{Question: "Continue?"})
+		utils.ExitIfError(err)
+		if !isConfirm {
+			return
+		}
+	}
+
+	cwd, _ := os.Getwd()
+	results, failed, err := migrateProjects(roots)
+	utils.ExitIfError(err)
+
+	cfg.log.Plain(markup.H1("Migration summary"))
+	showBatchMigrationResults(cwd, results)
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// migrateProjects migrates the projects one after the other, a failure does not stop the others.
+// failed is true when a project is not valid, has not been migrated or has pending changes.
+func migrateProjects(roots []string) (results []*projectMigrationResult, failed bool, err error) {
+	// each project is migrated from its own folder, the --rules path is relative to the current one.
+	if withMigrationRules != "" {
+		if withMigrationRules, err = filepath.Abs(withMigrationRules); err != nil {
+			return nil, false, err
+		}
+	}
+
+	cwd, _ := os.Getwd()
+	for _, root := range roots {
+		cfg.log.Plain(markup.H1(root))
+		r := migrateProjectAt(root)
+		results = append(results, r)
+		if r.status == projectFailed || r.status == projectNotValid || r.status == projectPending {
+			failed = true
+		}
+	}
+	// migrations work on the current directory, go back to the one the command was run from.
+	return results, failed, os.Chdir(cwd)
+}
+
+// migrateProjectAt runs the migrations pipeline for the project within the root folder.
+func migrateProjectAt(root string) *projectMigrationResult {
+	result := &projectMigrationResult{root: root, from: unknownSveltinVersion}
+
+	if exists, _ := afero.Exists(cfg.fs, filepath.Join(root, PackageJSONFile)); !exists {
+		result.status = projectNotValid
+		result.err = fmt.Errorf("%s not found", PackageJSONFile)
+		cfg.log.Error(result.err.Error())
+		return result
+	}
+	if err := os.Chdir(root); err != nil {
+		result.status = projectFailed
+		result.err = err
+		return result
+	}
+
+	if settings, err := readProjectSettings(cfg.fs, filepath.Join(root, ProjectSettingsFile)); err == nil && settings.Sveltin.Version != "" {
+		result.from = settings.Sveltin.Version
+	}
+
+	migrationServices, _, err := migrateProject(isMigrateDryRun)
+	if migrationServices != nil {
+		result.changes = len(migrationServices.GetChanges())
+		result.warnings = len(migrationServices.GetWarnings())
+	}
+
+	switch {
+	case err != nil:
+		result.status = projectFailed
+		result.err = err
+		cfg.log.Error(err.Error())
+	case result.changes == 0:
+		result.status = projectUpToDate
+	case isMigrateDryRun:
+		result.status = projectPending
+	default:
+		result.status = projectMigrated
+	}
+	return result
+}
+
+// resolveMigrationProjects returns the absolute paths to the project folders matching the value
+// of the --projects flag. It is a glob pattern or a file listing paths and globs, one per line.
+func resolveMigrationProjects(fs afero.Fs, value string) ([]string, error) {
+	patterns := []string{value}
+	baseDir, _ := os.Getwd()
+
+	if info, err := fs.Stat(value); err == nil && !info.IsDir() {
+		content, err := afero.ReadFile(fs, value)
+		if err != nil {
+			return nil, err
+		}
+		patterns, err = readProjectsList(content)
+		if err != nil {
+			return nil, err
+		}
+		// paths in the list are relative to the file itself.
+		if baseDir, err = filepath.Abs(filepath.Dir(value)); err != nil {
+			return nil, err
+		}
+	}
+
+	roots := []string{}
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(baseDir, pattern)
+		}
+		matches, err := afero.Glob(fs, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid projects pattern %s: %w", pattern, err)
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			if info, err := fs.Stat(match); err != nil || !info.IsDir() || seen[match] {
+				continue
+			}
+			seen[match] = true
+			roots = append(roots, match)
+		}
+	}
+	return roots, nil
+}
+
+// readProjectsList returns the not empty lines of a projects list file, skipping comments.
+func readProjectsList(content []byte) ([]string, error) {
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("the projects list file is empty")
+	}
+	return lines, nil
+}
+
+func showBatchMigrationResults(cwd string, results []*projectMigrationResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tFROM\tSTATUS\tCHANGES\tWARNINGS\tERROR")
+	for _, r := range results {
+		project := r.root
+		if rel, err := filepath.Rel(cwd, r.root); err == nil && !strings.HasPrefix(rel, "..") {
+			project = rel
+		}
+		errMsg := ""
+		if r.err != nil {
+			errMsg = strings.ReplaceAll(r.err.Error(), "\n", " ")
+			if len(errMsg) > errorColumnWidth {
+				errMsg = errMsg[:errorColumnWidth-3] + "..."
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\n", project, r.from, colorizeProjectMigrationStatus(r.status), r.changes, r.warnings, errMsg)
+	}
+	w.Flush()
+}
+
+func colorizeProjectMigrationStatus(status string) string {
+	switch status {
+	case projectMigrated, projectUpToDate:
+		return markup.Green(status)
+	case projectPending:
+		return markup.Amber(status)
+	default:
+		return markup.Red(status)
+	}
+}
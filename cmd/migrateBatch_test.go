@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/sveltinio/sveltin/config"
+	"github.com/sveltinio/sveltin/internal/fsm"
+	"github.com/sveltinio/sveltin/internal/pathmaker"
+	logger "github.com/sveltinio/yinlog"
+)
+
+const batchTestRules = `migrations:
+  - name: rename-old
+    target: src/*.ts
+    rules:
+      - trigger: old
+        replace: new
+frontmatter:
+  - name: move-author
+    rules:
+      - action: move
+        key: author
+        to: misc.author
+`
+
+func TestMigrateProjects(t *testing.T) {
+	is := is.New(t)
+	setupBatchTestConfig(t)
+
+	folder := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(folder, "rules.yaml"), []byte(batchTestRules), 0644))
+	// the --rules path is relative to the folder the command is run from.
+	cwd, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+	is.NoErr(os.Chdir(folder))
+	withMigrationRules = "rules.yaml"
+
+	// failing: the frontmatter migration fails after the user migration changed src/a.ts.
+	failing := newBatchTestProject(t, folder, "failing", "---\nauthor: [Jane\n---\n")
+	notValid := filepath.Join(folder, "not-valid")
+	is.NoErr(os.MkdirAll(notValid, 0755))
+	migrated := newBatchTestProject(t, folder, "migrated", "---\nauthor: Jane\n---\n")
+
+	results, failed, err := migrateProjects([]string{failing, notValid, migrated})
+	is.NoErr(err)
+	is.True(failed) // non-zero exit code
+	after, _ := os.Getwd()
+	is.Equal(after, folder)
+
+	is.Equal(len(results), 3) // continue on failure
+	is.Equal(results[0].status, projectFailed)
+	is.True(results[0].err != nil)
+	is.Equal(results[1].status, projectNotValid)
+	is.Equal(results[2].status, projectMigrated)
+	is.Equal(results[2].from, CliVersion)
+
+	// the failing project files are restored from the snapshot.
+	content, err := os.ReadFile(filepath.Join(failing, "src", "a.ts"))
+	is.NoErr(err)
+	is.Equal(string(content), "const old = 1;\n")
+	_, err = os.Stat(filepath.Join(failing, "migration-report.json"))
+	is.True(os.IsNotExist(err))
+
+	content, err = os.ReadFile(filepath.Join(migrated, "src", "a.ts"))
+	is.NoErr(err)
+	is.Equal(string(content), "const new = 1;\n")
+	content, err = os.ReadFile(filepath.Join(migrated, "content", "posts", "hello", "index.svx"))
+	is.NoErr(err)
+	is.Equal(string(content), "---\nmisc:\n  author: Jane\n---\n")
+
+	results, failed, err = migrateProjects([]string{migrated})
+	is.NoErr(err)
+	is.True(!failed)
+	is.Equal(results[0].status, projectUpToDate)
+}
+
+// newBatchTestProject creates a project, at the cli version, with a src/a.ts file and a content entry.
+func newBatchTestProject(t *testing.T, folder, name, entry string) string {
+	is := is.New(t)
+
+	root := filepath.Join(folder, name)
+	files := map[string]string{
+		PackageJSONFile:              "{\n  \"name\": \"" + name + "\"\n}\n",
+		ProjectSettingsFile:          "{\n  \"name\": \"" + name + "\",\n  \"sveltin\": {\"version\": \"" + CliVersion + "\"}\n}\n",
+		filepath.Join("src", "a.ts"): "const old = 1;\n",
+		filepath.Join("content", "posts", "hello", "index.svx"): entry,
+	}
+	for file, content := range files {
+		is.NoErr(os.MkdirAll(filepath.Dir(filepath.Join(root, file)), 0755))
+		is.NoErr(os.WriteFile(filepath.Join(root, file), []byte(content), 0644))
+	}
+	return root
+}
+
+// setupBatchTestConfig sets the app config as the root command does, the log is discarded.
+func setupBatchTestConfig(t *testing.T) {
+	is := is.New(t)
+
+	prevCfg, prevRules, prevDryRun := cfg, withMigrationRules, isMigrateDryRun
+	t.Cleanup(func() {
+		cfg, withMigrationRules, isMigrateDryRun = prevCfg, prevRules, prevDryRun
+	})
+
+	yamlFile, err := os.ReadFile(filepath.Join("..", "resources", "sveltin.yaml"))
+	is.NoErr(err)
+	v := viper.New()
+	v.SetConfigType("yaml")
+	is.NoErr(v.ReadConfig(bytes.NewBuffer(yamlFile)))
+	var settings config.SveltinSettings
+	is.NoErr(v.Unmarshal(&settings))
+
+	cfg.settings = &settings
+	cfg.log = logger.New()
+	cfg.log.SetPrinter(&logger.TextPrinter{Writer: io.Discard, Options: &logger.PrinterOptions{}})
+	cfg.pathMaker = pathmaker.NewSveltinPathMaker(cfg.settings)
+	cfg.fsManager = fsm.NewSveltinFSManager(cfg.pathMaker)
+	cfg.fs = afero.NewOsFs()
+	isMigrateDryRun = false
+}
@@ -0,0 +1,75 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/prompti/confirm"
+	"github.com/sveltinio/sveltin/internal/markup"
+	"github.com/sveltinio/sveltin/internal/migrations"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+//=============================================================================
+
+var migrateRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Restore the project files saved by the last migration",
+	Long: resources.GetASCIIArt() + `
+Command used to restore the project files as they were before running the last 'sveltin migrate'.
+
+Files are restored from the most recent snapshot within the 'backups/migrations' folder.
+Files created by the migration are removed. Use --yes to skip the confirmation.
+`,
+	DisableFlagsInUseLine: true,
+	Args:                  cobra.ExactArgs(0),
+	Run:                   RunMigrateRollbackCmd,
+}
+
+// RunMigrateRollbackCmd is the actual work function.
+func RunMigrateRollbackCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands from a not valid directory.
+	isValidProject(false)
+
+	snapshot, err := migrations.LoadLatestSnapshot(cfg.fs, cfg.pathMaker.GetRootFolder(), getMigrationSnapshotsFolder())
+	utils.ExitIfError(err)
+
+	cfg.log.Plain(markup.H1(fmt.Sprintf("Restoring files from the migration snapshot %s", snapshot.CreatedAt)))
+	for _, f := range snapshot.Files {
+		if f.IsNew {
+			cfg.log.Info(fmt.Sprintf("Removing %s", f.Path))
+		} else {
+			cfg.log.Info(fmt.Sprintf("Restoring %s", f.Path))
+		}
+	}
+
+	isConfirm := isMigrateYes
+	if !isConfirm {
+		isConfirm, err = confirm.Run(&confirm.Config{Question: "Continue?"})
+		utils.ExitIfError(err)
+	}
+
+	if isConfirm {
+		err = snapshot.Restore()
+		utils.ExitIfError(err)
+
+		cfg.log.Success("Done\n")
+	}
+}
+
+func migrateRollbackCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&isMigrateYes, "yes", "y", false, "Restore without asking for confirmation")
+}
+
+func init() {
+	migrateRollbackCmdFlags(migrateRollbackCmd)
+	migrateCmd.AddCommand(migrateRollbackCmd)
+}
@@ -0,0 +1,141 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/sveltinio/sveltin/internal/markup"
+	"github.com/sveltinio/sveltin/internal/migrations"
+	"github.com/sveltinio/sveltin/resources"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+var (
+	isMigrateStatusJSON bool
+)
+
+// migrationsStatusReport is the struct representing the migrations status for the project.
+type migrationsStatusReport struct {
+	ProjectVersion string                   `json:"projectVersion"`
+	CliVersion     string                   `json:"cliVersion"`
+	Migrations     []*migrationStatusReport `json:"migrations"`
+}
+
+// migrationStatusReport is the struct representing the status of a single migration.
+type migrationStatusReport struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Target string `json:"target"`
+}
+
+//=============================================================================
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations for your project",
+	Long: resources.GetASCIIArt() + `
+Command used to show where your project stands before running 'sveltin migrate'.
+
+It prints the sveltin version from sveltin.json, the cli version and, for each migration,
+whether it is applied, pending or not applicable and the file it works on.
+No files are changed.
+
+The --json flag prints the same information as JSON.
+`,
+	DisableFlagsInUseLine: true,
+	Args:                  cobra.ExactArgs(0),
+	Run:                   RunMigrateStatusCmd,
+}
+
+// RunMigrateStatusCmd is the actual work function.
+func RunMigrateStatusCmd(cmd *cobra.Command, args []string) {
+	// Exit if running sveltin commands from a not valid directory.
+	isValidProject(false)
+
+	report, err := newMigrationsStatusReport()
+	utils.ExitIfError(err)
+
+	if isMigrateStatusJSON {
+		content, err := json.MarshalIndent(report, "", "  ")
+		utils.ExitIfError(err)
+		fmt.Println(string(content))
+		return
+	}
+
+	projectVersion := report.ProjectVersion
+	if projectVersion == "" {
+		projectVersion = fmt.Sprintf("unknown (%s not found)", ProjectSettingsFile)
+	}
+	cfg.log.Plain(markup.H1("Migrations status"))
+	cfg.log.Plain(fmt.Sprintf("Project sveltin version: %s", markup.Bold(projectVersion)))
+	cfg.log.Plain(fmt.Sprintf("CLI sveltin version:     %s\n", markup.Bold(report.CliVersion)))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "MIGRATION\tTARGET\tSTATUS")
+	for _, m := range report.Migrations {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", m.ID, m.Target, colorizeMigrationStatus(m.Status))
+	}
+	w.Flush()
+}
+
+func migrateStatusCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&isMigrateStatusJSON, "json", "", false, "Print the migrations status as JSON")
+}
+
+func init() {
+	migrateStatusCmdFlags(migrateStatusCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+}
+
+//=============================================================================
+
+func newMigrationsStatusReport() (*migrationsStatusReport, error) {
+	cwd, _ := os.Getwd()
+	projectVersion := cfg.projectSettings.Sveltin.Version
+
+	statusMap, err := migrations.GetMigrationsStatus(projectVersion, CliVersion, cfg.projectSettings.Sveltin.Migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	targetsMap := getMigrationTargetsMap(cwd)
+	report := &migrationsStatusReport{
+		ProjectVersion: projectVersion,
+		CliVersion:     CliVersion,
+		Migrations:     []*migrationStatusReport{},
+	}
+	for _, id := range migrations.GetAllMigrations() {
+		target, err := filepath.Rel(cwd, targetsMap[id])
+		if err != nil {
+			target = targetsMap[id]
+		}
+		report.Migrations = append(report.Migrations, &migrationStatusReport{
+			ID:     id.String(),
+			Status: statusMap[id].String(),
+			Target: target,
+		})
+	}
+	return report, nil
+}
+
+func colorizeMigrationStatus(status string) string {
+	switch status {
+	case migrations.Applied.String():
+		return markup.Green(status)
+	case migrations.Pending.String():
+		return markup.Amber(status)
+	default:
+		return markup.Faint(status)
+	}
+}
@@ -8,7 +8,11 @@
 package cmd
 
 import (
+	"context"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/sveltinio/sveltin/helpers"
@@ -18,6 +22,10 @@ import (
 	"github.com/sveltinio/sveltin/utils"
 )
 
+var (
+	withServerWatch bool
+)
+
 //=============================================================================
 
 var serverCmd = &cobra.Command{
@@ -25,7 +33,11 @@ var serverCmd = &cobra.Command{
 	Aliases: []string{"s", "serve", "run", "dev"},
 	Short:   "Run the development server (vite)",
 	Long: resources.GetASCIIArt() + `
-It wraps vite dev to start a development server
+It wraps vite dev to start a development server.
+
+The --watch flag regenerates the menu, feeds, sitemap and search index when the 'content', 'src/routes'
+and 'config' folders change while the server runs (see 'sveltin generate all -h').
+Feeds are regenerated for the formats already saved in the 'static' folder.
 `,
 	DisableFlagsInUseLine: true,
 	Args:                  cobra.ExactArgs(0),
@@ -48,10 +60,25 @@ func RunServerCmd(cmd *cobra.Command, args []string) {
 	npmClient, err := utils.RetrievePackageManagerFromPkgJSON(cfg.fs, pathToPkgFile)
 	utils.ExitIfError(err)
 
+	if withServerWatch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			if err := watchAndGenerate(ctx, existingFeedFormats()); err != nil {
+				cfg.log.Error(err.Error())
+			}
+		}()
+	}
+
 	err = helpers.RunPMCommand(npmClient.Name, "dev", "", nil, false)
 	utils.ExitIfError(err)
 }
 
+func serverCmdFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&withServerWatch, "watch", "w", false, "Regenerate the menu, feeds, sitemap and search index when content, routes or config change")
+}
+
 func init() {
 	rootCmd.AddCommand(serverCmd)
+	serverCmdFlags(serverCmd)
 }
@@ -70,6 +70,12 @@ func (c *SveltinSettings) GetContentPath() string {
 	return c.Paths.Content
 }
 
+// GetArchetypesPath returns a string representing the path to the 'archetypes' folder
+// relative to the current working directory.
+func (c *SveltinSettings) GetArchetypesPath() string {
+	return c.Paths.Archetypes
+}
+
 // GetStaticPath returns a string representing the path to the 'static' folder
 // relative to the current working directory.
 func (c *SveltinSettings) GetStaticPath() string {
@@ -58,6 +58,7 @@ func TestPaths(t *testing.T) {
 		{path: settings.GetBuildPath(), want: filepath.Join(pwd, "build")},
 		{path: settings.GetConfigPath(), want: "config"},
 		{path: settings.GetContentPath(), want: "content"},
+		{path: settings.GetArchetypesPath(), want: "archetypes"},
 		{path: settings.GetStaticPath(), want: "static"},
 		{path: settings.GetSrcPath(), want: "src"},
 		{path: settings.GetRoutesPath(), want: filepath.Join("src", "routes")},
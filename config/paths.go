@@ -12,11 +12,13 @@ type Paths struct {
 	Build   string `mapstructure:"build"`
 	Config  string `mapstructure:"config"`
 	Content string `mapstructure:"content"`
-	Static  string `mapstructure:"static"`
-	Themes  string `mapstructure:"themes"`
-	Src     string `mapstructure:"src"`
-	Params  string `mapstructure:"params"`
-	Lib     string `mapstructure:"lib"`
-	Routes  string `mapstructure:"routes"`
-	API     string `mapstructure:"api"`
+	// Archetypes is the folder for the project templates used by 'sveltin add content'.
+	Archetypes string `mapstructure:"archetypes"`
+	Static     string `mapstructure:"static"`
+	Themes     string `mapstructure:"themes"`
+	Src        string `mapstructure:"src"`
+	Params     string `mapstructure:"params"`
+	Lib        string `mapstructure:"lib"`
+	Routes     string `mapstructure:"routes"`
+	API        string `mapstructure:"api"`
 }
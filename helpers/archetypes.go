@@ -0,0 +1,108 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/config"
+	"github.com/sveltinio/sveltin/internal/builder"
+)
+
+const (
+	// ArchetypeExt is the extension for the archetype files.
+	ArchetypeExt = ".svx"
+	// DefaultArchetype is the name of the archetype used when none is set.
+	DefaultArchetype = "default"
+)
+
+// GetArchetypes returns the names of the archetypes available for the resource: the ones within
+// the archetypes/<resource> folder and the ones shared by all resources within the archetypes folder.
+func GetArchetypes(fs afero.Fs, archetypesPath, resource string) []string {
+	names := []string{}
+	for _, folder := range []string{filepath.Join(archetypesPath, resource), archetypesPath} {
+		files, err := afero.ReadDir(fs, folder)
+		if err != nil {
+			continue
+		}
+		for _, f := range files {
+			if f.IsDir() || filepath.Ext(f.Name()) != ArchetypeExt {
+				continue
+			}
+			name := strings.TrimSuffix(f.Name(), ArchetypeExt)
+			if !common.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetArchetypeFile returns the path to the archetype for the resource, the one within the
+// archetypes/<resource> folder wins over the shared one. When name is empty, the path to the
+// default archetype is returned if any, an empty string otherwise.
+func GetArchetypeFile(fs afero.Fs, archetypesPath, resource, name string) (string, error) {
+	lookup := name
+	if lookup == "" {
+		lookup = DefaultArchetype
+	}
+	for _, folder := range []string{filepath.Join(archetypesPath, resource), archetypesPath} {
+		pathToFile := filepath.Join(folder, lookup+ArchetypeExt)
+		if exists, _ := common.FileExists(fs, pathToFile); exists {
+			return pathToFile, nil
+		}
+	}
+	if name == "" {
+		return "", nil
+	}
+	available := GetArchetypes(fs, archetypesPath, resource)
+	if len(available) == 0 {
+		return "", fmt.Errorf("archetype %q not found, no archetypes within the %s folder", name, archetypesPath)
+	}
+	return "", fmt.Errorf("archetype %q not found for %s, available archetypes are: %s", name, resource, strings.Join(available, ", "))
+}
+
+// ParseArchetypeParams returns the map of the key=value pairs. Values may contain commas and '='.
+func ParseArchetypeParams(values []string) (map[string]string, error) {
+	params := make(map[string]string)
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		key := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || key == "" {
+			return nil, fmt.Errorf("%q is not a valid value, use key=value", v)
+		}
+		params[key] = parts[1]
+	}
+	return params, nil
+}
+
+// RenderArchetype executes the archetype template with the content template functions.
+// Params not set with --set are empty strings.
+func RenderArchetype(fs afero.Fs, pathToFile string, data *config.TemplateData) ([]byte, error) {
+	text, err := afero.ReadFile(fs, pathToFile)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(filepath.Base(pathToFile)).Funcs(builder.ContentFuncs()).Option("missingkey=zero").Parse(string(text))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
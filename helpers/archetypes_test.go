@@ -0,0 +1,57 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/config"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+)
+
+func TestArchetypes(t *testing.T) {
+	is := is.New(t)
+
+	fs := afero.NewMemMapFs()
+	is.NoErr(afero.WriteFile(fs, "archetypes/default.svx", []byte("---\ntitle: {{ .Content.Name }}\n---\n"), 0644))
+	is.NoErr(afero.WriteFile(fs, "archetypes/talk.svx", []byte("shared"), 0644))
+	is.NoErr(afero.WriteFile(fs, "archetypes/talks/talk.svx", []byte("---\ntitle: {{ .Content.Name | ToTitle }}\nslug: {{ .Content.Name | ToSlug }}\nspeaker: {{ .Content.Params.speaker }}\nroom: {{ .Content.Params.room }}\nsite: {{ .ProjectSettings.BaseURL }}\n---\n"), 0644))
+	is.NoErr(afero.WriteFile(fs, "archetypes/talks/notes.md", []byte(""), 0644))
+
+	is.Equal(GetArchetypes(fs, "archetypes", "talks"), []string{"default", "talk"})
+	is.Equal(GetArchetypes(fs, "missing", "talks"), []string{})
+
+	pathToFile, err := GetArchetypeFile(fs, "archetypes", "talks", "talk")
+	is.NoErr(err)
+	is.Equal(pathToFile, "archetypes/talks/talk.svx") // the resource archetype wins
+	pathToFile, err = GetArchetypeFile(fs, "archetypes", "posts", "talk")
+	is.NoErr(err)
+	is.Equal(pathToFile, "archetypes/talk.svx")
+	pathToFile, err = GetArchetypeFile(fs, "archetypes", "posts", "")
+	is.NoErr(err)
+	is.Equal(pathToFile, "archetypes/default.svx")
+	pathToFile, err = GetArchetypeFile(fs, "missing", "posts", "")
+	is.NoErr(err)
+	is.Equal(pathToFile, "") // embedded templates
+	_, err = GetArchetypeFile(fs, "archetypes", "posts", "keynote")
+	is.True(err != nil)
+
+	params, err := ParseArchetypeParams([]string{"speaker=Jane, Doe", "query=a=b"})
+	is.NoErr(err)
+	is.Equal(params, map[string]string{"speaker": "Jane, Doe", "query": "a=b"})
+	_, err = ParseArchetypeParams([]string{"=value"})
+	is.True(err != nil)
+	_, err = ParseArchetypeParams([]string{"speaker"})
+	is.True(err != nil)
+
+	content, err := RenderArchetype(fs, "archetypes/talks/talk.svx", &config.TemplateData{
+		Content:         &tpltypes.ContentData{Name: "my talk", Resource: "talks", Params: params},
+		ProjectSettings: &tpltypes.ProjectSettings{BaseURL: "https://example.com"},
+	})
+	is.NoErr(err)
+	is.Equal(string(content), "---\ntitle: My Talk\nslug: my-talk\nspeaker: Jane, Doe\nroom: \nsite: https://example.com\n---\n")
+
+	is.NoErr(afero.WriteFile(fs, "archetypes/broken.svx", []byte("{{ .Content.Name"), 0644))
+	_, err = RenderArchetype(fs, "archetypes/broken.svx", &config.TemplateData{Content: &tpltypes.ContentData{}})
+	is.True(err != nil)
+}
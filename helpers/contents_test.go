@@ -4,10 +4,15 @@ package helpers
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 	"github.com/spf13/afero"
 	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/config"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+	"github.com/sveltinio/sveltin/resources"
 )
 
 func TestValidFileForContent(t *testing.T) {
@@ -58,3 +63,17 @@ func TestNotValidFileForContent(t *testing.T) {
 	}
 
 }
+
+func TestBlankContentFrontmatter(t *testing.T) {
+	is := is.New(t)
+
+	data := &config.TemplateData{Content: tpltypes.NewContentData("getting started", "posts", false)}
+	artifact := PrepareContent("resContent", resources.ContentFilesMap, data.Content.Type, data)
+	fm, _, err := content.ParseFrontmatter("index.svx", MakeFileContent(&resources.SveltinTemplatesFS, artifact))
+	is.NoErr(err) // the dates set by the template are parsed
+	is.Equal(fm.Title, "Getting Started")
+	is.Equal(fm.Slug, "getting-started")
+	today := time.Now().Format("2006-01-02")
+	is.Equal(fm.CreatedAt.Format("2006-01-02"), today)
+	is.Equal(fm.UpdatedAt.Format("2006-01-02"), today)
+}
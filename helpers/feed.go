@@ -0,0 +1,222 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+// DefaultFeedLimit is the default number of items within a feed.
+const DefaultFeedLimit = 20
+
+// Feed formats.
+const (
+	RSSFeed  string = "rss"
+	AtomFeed string = "atom"
+	JSONFeed string = "json"
+)
+
+// FeedFormats is the list of the supported feed formats.
+var FeedFormats = []string{RSSFeed, AtomFeed, JSONFeed}
+
+// FeedOptions is the struct representing the options to build a feed.
+type FeedOptions struct {
+	// Path is the path to the feed file relative to the website root (e.g. rss.xml).
+	Path string
+	// Section is the path to the page the feed is about relative to the website root
+	// (e.g. posts or posts/tags/go). The website root when empty.
+	Section string
+	// Title is the feed title. The website title when empty.
+	Title string
+	// Limit is the max number of items. All the entries are listed when 0.
+	Limit int
+	// FullContent adds the entry content to the items.
+	FullContent bool
+	// Metadata is the map of the metadata names by resource, used for the item categories.
+	Metadata map[string][]string
+	// LastModified returns the last modification time (e.g. from git or the file system) for the
+	// path to a content file, used for the entries with no created_at and updated_at. It may return a zero time.
+	LastModified func(pathToFile string) time.Time
+}
+
+var scriptOrStyleRegexp = regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(script|style)>`)
+
+// NewFeedData returns the feed for the content entries. Drafts are skipped and
+// the items are sorted by date, newest first. The feed is updated at the date of the
+// newest item or, when no item is dated, at the current time.
+func NewFeedData(website *tpltypes.WebSiteData, entries []*content.Entry, opts *FeedOptions) *tpltypes.FeedData {
+	published := []*content.Entry{}
+	for _, e := range entries {
+		if !e.Draft {
+			published = append(published, e)
+		}
+	}
+	content.SortByDate(published)
+	if opts.Limit > 0 && len(published) > opts.Limit {
+		published = published[:opts.Limit]
+	}
+
+	feed := &tpltypes.FeedData{
+		ID:          website.BaseURL + "/",
+		Title:       website.Title,
+		Description: website.Description,
+		Link:        website.BaseURL + "/",
+		FeedURL:     website.BaseURL + "/" + strings.TrimPrefix(opts.Path, "/"),
+		Language:    website.Language,
+		Copyright:   website.Copyright,
+		AuthorName:  website.CreatorName,
+		AuthorEmail: website.CreatorEmail,
+		FullContent: opts.FullContent,
+		Items:       []*tpltypes.FeedItem{},
+	}
+	if opts.Section != "" {
+		feed.Link = fmt.Sprintf("%s/%s/", website.BaseURL, strings.Trim(opts.Section, "/"))
+		feed.ID = feed.Link
+	}
+	if opts.Title != "" {
+		feed.Title = opts.Title
+	}
+	if feed.Description == "" {
+		feed.Description = fmt.Sprintf("Latest content from %s", feed.Title)
+	}
+
+	for _, e := range published {
+		link := website.BaseURL + e.URL()
+		item := &tpltypes.FeedItem{
+			Title:       e.Title,
+			Link:        link,
+			GUID:        link,
+			Description: e.Headline,
+			Author:      e.Author,
+			Categories:  entryCategories(e, opts.Metadata[e.Resource]),
+			Published:   e.CreatedAt,
+			Updated:     e.LastModified(),
+		}
+		if item.Updated.IsZero() && opts.LastModified != nil {
+			item.Updated = opts.LastModified(e.Path)
+		}
+		if item.Title == "" {
+			item.Title = e.Name
+		}
+		if opts.FullContent {
+			item.Content = strings.TrimSpace(scriptOrStyleRegexp.ReplaceAllString(string(e.Body), ""))
+		}
+		if item.Updated.After(feed.Updated) {
+			feed.Updated = item.Updated
+		}
+		feed.Items = append(feed.Items, item)
+	}
+	// atom requires the feed updated date, even when there is no dated item.
+	if feed.Updated.IsZero() {
+		feed.Updated = time.Now().UTC()
+	}
+	return feed
+}
+
+// DropUndatedFeedItems removes the items with no updated date from the feed and returns their links.
+func DropUndatedFeedItems(feed *tpltypes.FeedData) []string {
+	dropped := []string{}
+	items := []*tpltypes.FeedItem{}
+	for _, item := range feed.Items {
+		if item.Updated.IsZero() {
+			dropped = append(dropped, item.Link)
+			continue
+		}
+		items = append(items, item)
+	}
+	feed.Items = items
+	return dropped
+}
+
+// entryCategories returns the values set in the frontmatter for the metadata names.
+func entryCategories(e *content.Entry, metadata []string) []string {
+	categories := []string{}
+	for _, name := range metadata {
+		for _, v := range e.Values(utils.ToSnakeCase(name)) {
+			if !common.Contains(categories, v) {
+				categories = append(categories, v)
+			}
+		}
+	}
+	return categories
+}
+
+// FeedFilename returns the name of the file for the feed format.
+func FeedFilename(format string) string {
+	switch format {
+	case RSSFeed:
+		return "rss.xml"
+	case AtomFeed:
+		return "atom.xml"
+	case JSONFeed:
+		return "feed.json"
+	default:
+		return ""
+	}
+}
+
+// ValidateFeed returns an error listing the fields required by the feed format and missing within the feed.
+func ValidateFeed(format string, feed *tpltypes.FeedData) error {
+	missing := []string{}
+	require := func(field, value string) {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, field)
+		}
+	}
+
+	switch format {
+	case RSSFeed:
+		require("title", feed.Title)
+		require("link", feed.Link)
+		require("description", feed.Description)
+		for _, item := range feed.Items {
+			if item.Title == "" && item.Description == "" {
+				missing = append(missing, fmt.Sprintf("%s: title or description", item.Link))
+			}
+		}
+	case AtomFeed:
+		require("id", feed.ID)
+		require("title", feed.Title)
+		if feed.Updated.IsZero() {
+			missing = append(missing, "updated")
+		}
+		for _, item := range feed.Items {
+			require(item.Link+": id", item.GUID)
+			require(item.Link+": title", item.Title)
+			if item.Updated.IsZero() {
+				missing = append(missing, item.Link+": updated (set created_at or updated_at)")
+			}
+			if item.Author == "" && feed.AuthorName == "" {
+				missing = append(missing, item.Link+": author")
+			}
+		}
+	case JSONFeed:
+		require("title", feed.Title)
+		for _, item := range feed.Items {
+			require(item.Link+": id", item.GUID)
+			if item.Description == "" && item.Content == "" {
+				missing = append(missing, item.Link+": content (set headline or use the full content)")
+			}
+		}
+	default:
+		return fmt.Errorf("%s is not a valid feed format", format)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("the %s feed is missing required fields: %s", format, strings.Join(missing, ", "))
+	}
+	return nil
+}
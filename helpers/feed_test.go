@@ -0,0 +1,128 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+)
+
+func TestNewFeedData(t *testing.T) {
+	is := is.New(t)
+
+	website := &tpltypes.WebSiteData{Title: "My Site", BaseURL: "https://example.com", Language: "en"}
+	entries := []*content.Entry{
+		newFeedEntry("posts", "old", "2023-01-01", false, map[string]interface{}{"category": "go"}),
+		newFeedEntry("posts", "draft", "2023-03-01", true, nil),
+		newFeedEntry("posts", "new", "2023-02-01", false, map[string]interface{}{"tags": []interface{}{"svelte", "go"}}),
+		newFeedEntry("talks", "first", "2022-12-01", false, nil),
+	}
+	entries[2].Slug = "new-post"
+	entries[2].Body = []byte("<script>\n\timport A from 'a';\n</script>\n\n## Title\n")
+
+	feed := NewFeedData(website, entries, &FeedOptions{
+		Path:        "rss.xml",
+		Limit:       2,
+		FullContent: true,
+		Metadata:    map[string][]string{"posts": {"category", "tags"}},
+	})
+	is.Equal(feed.FeedURL, "https://example.com/rss.xml")
+	is.Equal(feed.Description, "Latest content from My Site")
+	is.Equal(len(feed.Items), 2) // draft skipped and limit applied
+	is.Equal(feed.Items[0].Link, "https://example.com/posts/new-post/")
+	is.Equal(feed.Items[0].GUID, feed.Items[0].Link)
+	is.Equal(feed.Items[0].Categories, []string{"svelte", "go"})
+	is.Equal(feed.Items[0].Content, "## Title")
+	is.Equal(feed.Items[1].Categories, []string{"go"})
+	is.Equal(feed.Updated, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func newFeedEntry(resource, name, date string, draft bool, extra map[string]interface{}) *content.Entry {
+	createdAt, _ := time.Parse("2006-01-02", date)
+	return &content.Entry{
+		Resource: resource,
+		Name:     name,
+		Frontmatter: content.Frontmatter{
+			Title:     name,
+			Headline:  "About " + name,
+			Draft:     draft,
+			CreatedAt: createdAt,
+			Extra:     extra,
+		},
+	}
+}
+
+func TestValidateFeed(t *testing.T) {
+	is := is.New(t)
+
+	website := &tpltypes.WebSiteData{Title: "My Site", BaseURL: "https://example.com", CreatorName: "Jane"}
+	dated := []*content.Entry{newFeedEntry("posts", "hello", "2023-01-01", false, nil)}
+	undated := []*content.Entry{newFeedEntry("posts", "hello", "", false, nil)}
+
+	for _, format := range FeedFormats {
+		feed := NewFeedData(website, dated, &FeedOptions{Path: FeedFilename(format)})
+		is.NoErr(ValidateFeed(format, feed))
+	}
+
+	feed := NewFeedData(website, undated, &FeedOptions{Path: FeedFilename(AtomFeed)})
+	is.NoErr(ValidateFeed(RSSFeed, feed))
+	is.True(ValidateFeed(AtomFeed, feed) != nil) // missing item updated
+
+	feed.ID = ""
+	err := ValidateFeed(AtomFeed, feed)
+	is.True(strings.Contains(err.Error(), "id, "))
+
+	is.True(ValidateFeed("rdf", feed) != nil)
+}
+
+func TestFeedNoDatedEntries(t *testing.T) {
+	is := is.New(t)
+
+	website := &tpltypes.WebSiteData{Title: "My Site", BaseURL: "https://example.com", CreatorName: "Jane"}
+	drafts := []*content.Entry{newFeedEntry("posts", "draft", "2023-01-01", true, nil)}
+	before := time.Now()
+
+	for _, format := range FeedFormats {
+		feed := NewFeedData(website, drafts, &FeedOptions{Path: FeedFilename(format)})
+		is.Equal(len(feed.Items), 0)
+		is.True(!feed.Updated.Before(before)) // the current time
+		is.NoErr(ValidateFeed(format, feed))
+	}
+
+	// all the atom entries are undated and dropped.
+	feed := NewFeedData(website, []*content.Entry{newFeedEntry("posts", "hello", "", false, nil)}, &FeedOptions{Path: FeedFilename(AtomFeed)})
+	is.Equal(len(DropUndatedFeedItems(feed)), 1)
+	is.NoErr(ValidateFeed(AtomFeed, feed))
+}
+
+func TestFeedUndatedEntries(t *testing.T) {
+	is := is.New(t)
+
+	website := &tpltypes.WebSiteData{Title: "My Site", BaseURL: "https://example.com", CreatorName: "Jane"}
+	entries := []*content.Entry{
+		newFeedEntry("posts", "tracked", "", false, nil),
+		newFeedEntry("posts", "missing", "", false, nil),
+	}
+	entries[0].Path = "content/posts/tracked/index.svx"
+	fileTime := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	feed := NewFeedData(website, entries, &FeedOptions{
+		Path: FeedFilename(AtomFeed),
+		LastModified: func(pathToFile string) time.Time {
+			if pathToFile == entries[0].Path {
+				return fileTime
+			}
+			return time.Time{}
+		},
+	})
+	is.Equal(feed.Items[0].Updated, fileTime) // git or file date
+	is.Equal(feed.Updated, fileTime)
+	is.True(feed.Items[1].Updated.IsZero())
+
+	is.Equal(DropUndatedFeedItems(feed), []string{"https://example.com/posts/missing/"})
+	is.Equal(len(feed.Items), 1)
+	is.NoErr(ValidateFeed(AtomFeed, feed))
+}
@@ -0,0 +1,61 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package helpers
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GitDates is the time of the last commit by file path, relative to the folder git log ran from.
+type GitDates map[string]time.Time
+
+// ParseGitDates returns the dates from the output of git log --name-only --format=%cI,
+// newest commits first.
+func ParseGitDates(out []byte) GitDates {
+	dates := GitDates{}
+	var commitTime time.Time
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, line); err == nil {
+			commitTime = t
+			continue
+		}
+		if _, exists := dates[line]; !exists && !commitTime.IsZero() {
+			dates[line] = commitTime
+		}
+	}
+	return dates
+}
+
+// LastCommit returns the time of the last commit for the file or, for a folder, for any file
+// within it. The path is relative to the folder git log ran from.
+func (d GitDates) LastCommit(pathToFile string) (time.Time, bool) {
+	key := filepath.ToSlash(filepath.Clean(pathToFile))
+	if t, ok := d[key]; ok {
+		return t, true
+	}
+	prefix := key + "/"
+	if key == "." {
+		prefix = ""
+	}
+	var last time.Time
+	for file, t := range d {
+		if strings.HasPrefix(file, prefix) && t.After(last) {
+			last = t
+		}
+	}
+	return last, !last.IsZero()
+}
@@ -0,0 +1,42 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestGitDates(t *testing.T) {
+	is := is.New(t)
+
+	out := []byte(`2023-03-01T10:00:00+01:00
+
+content/posts/hello/index.svx
+src/routes/about/+page.svelte
+2023-02-01T10:00:00Z
+
+src/routes/about/+page.svelte
+src/routes/docs/intro/+page.svx
+2023-01-01T10:00:00Z
+`)
+	dates := ParseGitDates(out)
+	is.Equal(len(dates), 3)
+
+	last, ok := dates.LastCommit("src/routes/about/+page.svelte")
+	is.True(ok)
+	is.True(last.Equal(time.Date(2023, 3, 1, 9, 0, 0, 0, time.UTC))) // newest commit wins
+
+	last, ok = dates.LastCommit("src/routes/docs/")
+	is.True(ok)
+	is.True(last.Equal(time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC))) // any file within the folder
+
+	last, ok = dates.LastCommit("src/routes")
+	is.True(ok)
+	is.True(last.Equal(time.Date(2023, 3, 1, 9, 0, 0, 0, time.UTC)))
+
+	_, ok = dates.LastCommit("src/route")
+	is.True(!ok) // not a folder prefix
+	_, ok = ParseGitDates(nil).LastCommit("content")
+	is.True(!ok)
+}
@@ -0,0 +1,201 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package helpers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+// List output formats.
+const (
+	TableOutput string = "table"
+	JSONOutput  string = "json"
+	CSVOutput   string = "csv"
+)
+
+// ListOutputs is the list of the supported output formats for the list commands.
+var ListOutputs = []string{TableOutput, JSONOutput, CSVOutput}
+
+// Route kinds.
+const (
+	HomeRoute     string = "home"
+	ResourceRoute string = "resource"
+	MetadataRoute string = "metadata"
+	PageRoute     string = "page"
+	DynamicRoute  string = "dynamic"
+)
+
+// ListTable is the struct representing the rows printed by the list commands.
+// Cells are strings, ints or bools so that JSON keeps their type.
+type ListTable struct {
+	// Columns are the lowercase column names, used as JSON keys and to sort the rows.
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// NewListTable returns an empty table with the columns.
+func NewListTable(columns ...string) *ListTable {
+	return &ListTable{Columns: columns, Rows: [][]interface{}{}}
+}
+
+// Append adds a row to the table.
+func (t *ListTable) Append(cells ...interface{}) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Sort sorts the rows by the column, numbers by value and the other cells as strings.
+// The sort is stable so rows with the same value keep their order.
+func (t *ListTable) Sort(column string, reverse bool) error {
+	col := -1
+	for i, c := range t.Columns {
+		if c == column {
+			col = i
+		}
+	}
+	if col < 0 {
+		return fmt.Errorf("cannot sort by %q, valid columns are: %s", column, strings.Join(t.Columns, ", "))
+	}
+	sort.SliceStable(t.Rows, func(i, j int) bool {
+		a, b := t.Rows[i][col], t.Rows[j][col]
+		if reverse {
+			a, b = b, a
+		}
+		if x, ok := a.(int); ok {
+			if y, ok := b.(int); ok {
+				return x < y
+			}
+		}
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	})
+	return nil
+}
+
+// Write prints the table to w in the output format.
+func (t *ListTable) Write(w io.Writer, format string) error {
+	switch format {
+	case TableOutput:
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(t.Columns, "\t")))
+		for _, row := range t.Rows {
+			fmt.Fprintln(tw, strings.Join(t.cells(row), "\t"))
+		}
+		return tw.Flush()
+	case CSVOutput:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(t.Columns); err != nil {
+			return err
+		}
+		for _, row := range t.Rows {
+			if err := cw.Write(t.cells(row)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case JSONOutput:
+		records := []map[string]interface{}{}
+		for _, row := range t.Rows {
+			record := make(map[string]interface{}, len(t.Columns))
+			for i, c := range t.Columns {
+				record[c] = row[i]
+			}
+			records = append(records, record)
+		}
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	default:
+		return fmt.Errorf("%q is not a valid output format, valid formats are: %s", format, strings.Join(ListOutputs, ", "))
+	}
+}
+
+func (t *ListTable) cells(row []interface{}) []string {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		cells[i] = fmt.Sprint(cell)
+	}
+	return cells
+}
+
+// FilterContentEntries returns the entries for the resource (any when empty) with the draft status
+// (any when nil) and the tag among their keywords or their values for the metadata (any when empty).
+func FilterContentEntries(entries []*content.Entry, metadata map[string][]string, resource string, draft *bool, tag string) []*content.Entry {
+	filtered := []*content.Entry{}
+	for _, e := range entries {
+		if resource != "" && e.Resource != resource {
+			continue
+		}
+		if draft != nil && e.Draft != *draft {
+			continue
+		}
+		if tag != "" && !common.Contains(e.Keywords, tag) && !common.Contains(entryCategories(e, metadata[e.Resource]), tag) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+// GetRouteKind returns the kind of the route as returned by GetAllRoutes.
+func GetRouteKind(route string, resources []string, metadata map[string][]string) string {
+	segments := strings.Split(route, "/")
+	switch {
+	case route == "":
+		return HomeRoute
+	case strings.Contains(route, "["):
+		return DynamicRoute
+	case len(segments) == 1 && common.Contains(resources, segments[0]):
+		return ResourceRoute
+	case len(segments) == 2 && common.Contains(metadata[segments[0]], segments[1]):
+		return MetadataRoute
+	default:
+		return PageRoute
+	}
+}
+
+// GetPublicPages returns the map of the public pages by route with the type of their page file
+// (svelte or markdown). Resource, metadata and dynamic routes are skipped.
+func GetPublicPages(fs afero.Fs, routesPath string, routes, resources []string, metadata map[string][]string) map[string]string {
+	pages := make(map[string]string)
+	for _, route := range routes {
+		if GetRouteKind(route, resources, metadata) != PageRoute {
+			continue
+		}
+		for _, pageType := range []string{"svelte", "markdown"} {
+			if exists, _ := common.FileExists(fs, filepath.Join(routesPath, route, PublicPageFilename(pageType))); exists {
+				pages[route] = pageType
+				break
+			}
+		}
+	}
+	return pages
+}
+
+// PageTitle returns the title in the frontmatter of the page, if any, the title case of its name otherwise.
+func PageTitle(route string, fm *content.Frontmatter) string {
+	if fm != nil && fm.Title != "" {
+		return fm.Title
+	}
+	return utils.ToTitle(path.Base(route))
+}
@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/content"
+)
+
+func TestListTable(t *testing.T) {
+	is := is.New(t)
+
+	table := NewListTable("slug", "words", "draft")
+	table.Append("hello", 120, false)
+	table.Append("about", 9, true)
+	table.Append("world", 30, false)
+
+	is.NoErr(table.Sort("words", false))
+	is.Equal(table.Rows[0][0], "about") // numbers sorted by value
+	is.NoErr(table.Sort("slug", true))
+	is.Equal(table.Rows[0][0], "world")
+	is.True(table.Sort("title", false) != nil)
+
+	var buf bytes.Buffer
+	is.NoErr(table.Write(&buf, CSVOutput))
+	is.Equal(buf.String(), "slug,words,draft\nworld,30,false\nhello,120,false\nabout,9,true\n")
+
+	buf.Reset()
+	is.NoErr(table.Write(&buf, TableOutput))
+	is.Equal(buf.String(), "SLUG    WORDS   DRAFT\nworld   30      false\nhello   120     false\nabout   9       true\n")
+
+	buf.Reset()
+	is.NoErr(NewListTable("slug", "words").Write(&buf, JSONOutput))
+	is.Equal(buf.String(), "[]\n")
+	table.Rows = table.Rows[:1]
+	buf.Reset()
+	is.NoErr(table.Write(&buf, JSONOutput))
+	is.Equal(buf.String(), "[\n  {\n    \"draft\": false,\n    \"slug\": \"world\",\n    \"words\": 30\n  }\n]\n")
+
+	is.True(table.Write(&buf, "xml") != nil)
+}
+
+func TestFilterContentEntries(t *testing.T) {
+	is := is.New(t)
+
+	entries := []*content.Entry{
+		{Resource: "posts", Name: "hello", Frontmatter: content.Frontmatter{Keywords: []string{"svelte"}}},
+		{Resource: "posts", Name: "draft", Frontmatter: content.Frontmatter{Draft: true, Extra: map[string]interface{}{"tags": []interface{}{"go"}}}},
+		{Resource: "pages", Name: "about", Frontmatter: content.Frontmatter{Extra: map[string]interface{}{"tags": "go"}}},
+	}
+	metadata := map[string][]string{"posts": {"tags"}}
+	names := func(entries []*content.Entry) []string {
+		list := []string{}
+		for _, e := range entries {
+			list = append(list, e.Name)
+		}
+		return list
+	}
+	published := false
+
+	is.Equal(names(FilterContentEntries(entries, metadata, "", nil, "")), []string{"hello", "draft", "about"})
+	is.Equal(names(FilterContentEntries(entries, metadata, "posts", &published, "")), []string{"hello"})
+	is.Equal(names(FilterContentEntries(entries, metadata, "", nil, "svelte")), []string{"hello"})
+	is.Equal(names(FilterContentEntries(entries, metadata, "", nil, "go")), []string{"draft"}) // tags is not a metadata for pages
+}
+
+func TestGetRouteKindAndPublicPages(t *testing.T) {
+	is := is.New(t)
+
+	resources := []string{"posts"}
+	metadata := map[string][]string{"posts": {"tags"}}
+	is.Equal(GetRouteKind("", resources, metadata), HomeRoute)
+	is.Equal(GetRouteKind("posts", resources, metadata), ResourceRoute)
+	is.Equal(GetRouteKind("posts/tags", resources, metadata), MetadataRoute)
+	is.Equal(GetRouteKind("posts/[slug]", resources, metadata), DynamicRoute)
+	is.Equal(GetRouteKind("about", resources, metadata), PageRoute)
+
+	fs := afero.NewMemMapFs()
+	is.NoErr(afero.WriteFile(fs, "src/routes/about/+page.svelte", []byte(""), 0644))
+	is.NoErr(afero.WriteFile(fs, "src/routes/docs/intro/+page.svx", []byte("---\ntitle: Intro\n---\n"), 0644))
+	is.NoErr(afero.WriteFile(fs, "src/routes/posts/+page.svelte", []byte(""), 0644))
+	routes := []string{"about", "docs", "docs/intro", "posts", "posts/tags"}
+	is.Equal(GetPublicPages(fs, "src/routes", routes, resources, metadata), map[string]string{"about": "svelte", "docs/intro": "markdown"})
+	is.Equal(PageTitle("docs/intro", nil), "Intro")
+	is.Equal(PageTitle("docs/getting-started", &content.Frontmatter{}), "Getting Started")
+}
@@ -7,12 +7,260 @@
 
 package helpers
 
-import "github.com/sveltinio/sveltin/internal/tpltypes"
-
-// NewMenuItems return a NoPageItems.
-func NewMenuItems(resources []string, content map[string][]string) *tpltypes.MenuItems {
-	r := new(tpltypes.MenuItems)
-	r.Resources = resources
-	r.Content = content
-	return r
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+// menuFrontmatterKey is the frontmatter key used to set the menu item for a content entry or a page.
+const menuFrontmatterKey = "menu"
+
+// MenuTopLevel is the parent value moving an item to the top level of the menu.
+const MenuTopLevel = "/"
+
+// MenuSources is the struct representing the data the menu is built from.
+type MenuSources struct {
+	// Routes are the routes as returned by GetAllRoutes.
+	Routes  []string
+	Entries []*content.Entry
+	// Pages is the frontmatter of the markdown public pages by route.
+	Pages    map[string]*content.Frontmatter
+	Settings *tpltypes.MenuSettings
+	// WithContent adds all the content entries to the menu, not only the ones with the menu key set.
+	WithContent bool
+}
+
+// menuNode is a menu item while building the menu tree.
+type menuNode struct {
+	item   *tpltypes.MenuItem
+	parent string
+	// weight is the weight set in the frontmatter or in the settings, 0 when not set.
+	weight int
+	hidden bool
+}
+
+// NewMenuItems returns the menu tree for the home page, the routes and the content entries.
+//
+// By default routes are nested below the closest parent route (e.g. posts/tags below posts),
+// content entries below their resource and items are sorted as listed. The menu key in the
+// frontmatter and the menu items in the settings set weight, label, parent and hidden for an
+// item, the settings take precedence. Hidden items are not listed, together with their children.
+func NewMenuItems(src *MenuSources) ([]*tpltypes.MenuItem, []error) {
+	errs := []error{}
+	nodes := map[string]*menuNode{}
+	order := []string{}
+	add := func(n *menuNode) {
+		nodes[n.item.Identifier] = n
+		order = append(order, n.item.Identifier)
+	}
+
+	add(&menuNode{item: &tpltypes.MenuItem{Identifier: "home", Name: "Home", URL: "/"}})
+
+	for _, route := range src.Routes {
+		if route == "" || strings.Contains(route, "[") {
+			continue
+		}
+		if _, ok := nodes[route]; ok {
+			continue
+		}
+		n := &menuNode{
+			item:   &tpltypes.MenuItem{Identifier: route, Name: utils.ToTitle(path.Base(route)), URL: utils.ToURL(route)},
+			parent: closestParent(route, nodes),
+		}
+		if fm, ok := src.Pages[route]; ok {
+			if err := applyMenuFrontmatter(n, fm); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", route, err))
+			}
+		}
+		add(n)
+	}
+
+	for _, e := range src.Entries {
+		identifier := path.Join(e.Resource, e.Name)
+		if _, hasMenu := e.Get(menuFrontmatterKey); e.Draft || (!src.WithContent && !hasMenu) {
+			continue
+		}
+		if _, ok := nodes[identifier]; ok {
+			continue
+		}
+		n := &menuNode{
+			item: &tpltypes.MenuItem{Identifier: identifier, Name: e.Title, URL: utils.ToURL(path.Join(e.Resource, e.PageSlug()))},
+		}
+		if _, ok := nodes[e.Resource]; ok {
+			n.parent = e.Resource
+		}
+		if n.item.Name == "" {
+			n.item.Name = utils.ToTitle(e.Name)
+		}
+		if err := applyMenuFrontmatter(n, &e.Frontmatter); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Path, err))
+		}
+		add(n)
+	}
+
+	if src.Settings != nil {
+		for _, s := range src.Settings.Items {
+			n, ok := nodes[s.Identifier]
+			if !ok {
+				if s.Identifier == "" || s.URL == "" {
+					errs = append(errs, fmt.Errorf("menu: %q is not a route or a content entry, set the url for custom items", s.Identifier))
+					continue
+				}
+				n = &menuNode{item: &tpltypes.MenuItem{Identifier: s.Identifier, Name: s.Identifier}}
+				add(n)
+			}
+			if s.Label != "" {
+				n.item.Name = s.Label
+			}
+			if s.URL != "" {
+				n.item.URL = s.URL
+				n.item.External = utils.IsValidURL(s.URL)
+			}
+			if s.Weight != 0 {
+				n.weight = s.Weight
+			}
+			if s.Parent != "" {
+				n.parent = s.Parent
+			}
+			if s.Hidden {
+				n.hidden = true
+			}
+		}
+	}
+
+	children := map[string][]*menuNode{}
+	for _, identifier := range order {
+		n := nodes[identifier]
+		if n.hidden {
+			continue
+		}
+		parent := n.parent
+		if parent == MenuTopLevel {
+			parent = ""
+		}
+		if parent != "" {
+			if _, ok := nodes[parent]; !ok {
+				errs = append(errs, fmt.Errorf("menu: the parent %q of %q is not a menu item", parent, identifier))
+				parent = ""
+			} else if isMenuAncestor(nodes, identifier, parent) {
+				errs = append(errs, fmt.Errorf("menu: %q cannot be nested below %q, it is one of its parents", identifier, parent))
+				parent = ""
+			}
+			// breaks the cycle for the items checked next
+			n.parent = parent
+		}
+		children[parent] = append(children[parent], n)
+	}
+
+	var build func(parent string, depth int) []*tpltypes.MenuItem
+	build = func(parent string, depth int) []*tpltypes.MenuItem {
+		siblings := children[parent]
+		for i, n := range siblings {
+			n.item.Weight = i + 1
+			if n.weight != 0 {
+				n.item.Weight = n.weight
+			}
+		}
+		sort.SliceStable(siblings, func(i, j int) bool {
+			return siblings[i].item.Weight < siblings[j].item.Weight
+		})
+		items := []*tpltypes.MenuItem{}
+		for _, n := range siblings {
+			n.item.Depth = depth
+			n.item.Children = build(n.item.Identifier, depth+1)
+			items = append(items, n.item)
+		}
+		return items
+	}
+	return build("", 1), errs
+}
+
+// GetPagesFrontmatter returns the frontmatter of the markdown public pages (+page.svx) for the routes.
+func GetPagesFrontmatter(fs afero.Fs, routesPath string, routes []string) (map[string]*content.Frontmatter, []error) {
+	pages := make(map[string]*content.Frontmatter)
+	errs := []error{}
+	for _, route := range routes {
+		pathToFile := filepath.Join(routesPath, route, PublicPageFilename("markdown"))
+		data, err := afero.ReadFile(fs, pathToFile)
+		if err != nil {
+			continue
+		}
+		fm, _, err := content.ParseFrontmatter(pathToFile, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		pages[route] = fm
+	}
+	return pages, errs
+}
+
+// closestParent returns the closest route already listed among the parent folders of the route.
+func closestParent(route string, nodes map[string]*menuNode) string {
+	for parent := path.Dir(route); parent != "." && parent != "/"; parent = path.Dir(parent) {
+		if _, ok := nodes[parent]; ok {
+			return parent
+		}
+	}
+	return ""
+}
+
+// isMenuAncestor returns true when identifier is found walking up the parents from parent.
+func isMenuAncestor(nodes map[string]*menuNode, identifier, parent string) bool {
+	for i := 0; i < len(nodes) && parent != ""; i++ {
+		if parent == identifier {
+			return true
+		}
+		n, ok := nodes[parent]
+		if !ok {
+			return false
+		}
+		parent = n.parent
+	}
+	return false
+}
+
+// applyMenuFrontmatter sets weight, label, parent and hidden from the menu key in the frontmatter.
+func applyMenuFrontmatter(n *menuNode, fm *content.Frontmatter) error {
+	value, ok := fm.Get(menuFrontmatterKey)
+	if !ok || value == nil {
+		return nil
+	}
+	settings, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be a map with weight, label, parent and hidden", menuFrontmatterKey)
+	}
+
+	for key, v := range settings {
+		switch key {
+		case "weight":
+			weight, err := strconv.Atoi(fmt.Sprint(v))
+			if err != nil {
+				return fmt.Errorf("%s.weight: %v is not an integer", menuFrontmatterKey, v)
+			}
+			n.weight = weight
+		case "label":
+			n.item.Name = fmt.Sprint(v)
+		case "parent":
+			n.parent = fmt.Sprint(v)
+		case "hidden":
+			hidden, ok := v.(bool)
+			if !ok {
+				return fmt.Errorf("%s.hidden: %v is not a boolean", menuFrontmatterKey, v)
+			}
+			n.hidden = hidden
+		default:
+			return fmt.Errorf("%s.%s is not valid (weight, label, parent, hidden)", menuFrontmatterKey, key)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,91 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+)
+
+func menuIdentifiers(items []*tpltypes.MenuItem) []string {
+	identifiers := []string{}
+	for _, item := range items {
+		identifiers = append(identifiers, item.Identifier)
+	}
+	return identifiers
+}
+
+func TestNewMenuItems(t *testing.T) {
+	is := is.New(t)
+
+	src := &MenuSources{
+		Routes: []string{"about", "posts", "posts/tags", "posts/[slug]", "blog"},
+		Entries: []*content.Entry{
+			{Resource: "posts", Name: "hello", Frontmatter: content.Frontmatter{Title: "Hello World"}},
+			{Resource: "posts", Name: "draft", Frontmatter: content.Frontmatter{Draft: true}},
+			{Resource: "posts", Name: "pinned", Frontmatter: content.Frontmatter{Slug: "pinned-post", Extra: map[string]interface{}{
+				"menu": map[string]interface{}{"weight": -1, "label": "Pinned post"},
+			}}},
+		},
+	}
+
+	// content entries with the menu key only
+	items, errs := NewMenuItems(src)
+	is.Equal(len(errs), 0)
+	is.Equal(menuIdentifiers(items), []string{"home", "about", "posts", "blog"})
+	is.Equal(items[0].Weight, 1)
+	is.Equal(items[2].Depth, 1)
+	is.Equal(menuIdentifiers(items[2].Children), []string{"posts/pinned", "posts/tags"})
+	is.Equal(items[2].Children[0].Name, "Pinned post")
+	is.Equal(items[2].Children[0].URL, "/posts/pinned-post")
+	is.Equal(items[2].Children[0].Depth, 2)
+
+	src.WithContent = true
+	src.Pages = map[string]*content.Frontmatter{
+		"about": {Extra: map[string]interface{}{"menu": map[string]interface{}{"weight": 10}}},
+	}
+	src.Settings = &tpltypes.MenuSettings{Items: []tpltypes.MenuItemSettings{
+		{Identifier: "posts", Label: "Blog", Weight: 2},
+		{Identifier: "posts/tags", Parent: MenuTopLevel},
+		{Identifier: "blog", Hidden: true},
+		{Identifier: "github", Label: "GitHub", URL: "https://github.com/sveltinio", Weight: 20},
+	}}
+	items, errs = NewMenuItems(src)
+	is.Equal(len(errs), 0)
+	is.Equal(menuIdentifiers(items), []string{"home", "posts", "posts/tags", "about", "github"})
+	is.Equal(items[1].Name, "Blog")
+	is.Equal(menuIdentifiers(items[1].Children), []string{"posts/pinned", "posts/hello"}) // drafts skipped
+	is.Equal(items[1].Children[1].Name, "Hello World")
+	is.True(items[4].External)
+
+	tests := []*MenuSources{
+		{Routes: []string{"about"}, Settings: &tpltypes.MenuSettings{Items: []tpltypes.MenuItemSettings{{Identifier: "missing"}}}},
+		{Routes: []string{"about"}, Settings: &tpltypes.MenuSettings{Items: []tpltypes.MenuItemSettings{{Identifier: "about", Parent: "missing"}}}},
+		{Routes: []string{"a", "a/b"}, Settings: &tpltypes.MenuSettings{Items: []tpltypes.MenuItemSettings{{Identifier: "a", Parent: "a/b"}}}},
+		{Routes: []string{"about"}, Pages: map[string]*content.Frontmatter{"about": {Extra: map[string]interface{}{"menu": map[string]interface{}{"weight": "first"}}}}},
+		{Routes: []string{"about"}, Pages: map[string]*content.Frontmatter{"about": {Extra: map[string]interface{}{"menu": map[string]interface{}{"order": 1}}}}},
+	}
+	for _, tc := range tests {
+		items, errs := NewMenuItems(tc)
+		is.Equal(len(errs), 1)
+		is.True(len(items) > 0)
+	}
+
+	_, errs = NewMenuItems(tests[2])
+	is.Equal(errs[0].Error(), `menu: "a" cannot be nested below "a/b", it is one of its parents`)
+}
+
+func TestGetPagesFrontmatter(t *testing.T) {
+	is := is.New(t)
+
+	memFs := afero.NewMemMapFs()
+	is.NoErr(afero.WriteFile(memFs, "src/routes/about/+page.svx", []byte("---\ntitle: About\nmenu:\n  label: About us\n---\n"), 0644))
+	is.NoErr(afero.WriteFile(memFs, "src/routes/contact/+page.svelte", []byte("<h1>Contact</h1>"), 0644))
+
+	pages, errs := GetPagesFrontmatter(memFs, "src/routes", []string{"about", "contact"})
+	is.Equal(len(errs), 0)
+	is.Equal(len(pages), 1)
+	is.Equal(pages["about"].Title, "About")
+}
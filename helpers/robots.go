@@ -0,0 +1,49 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+)
+
+// ProductionEnv is the name of the environment the website is published to.
+const ProductionEnv = "production"
+
+// NewRobotsTxtData returns the data for the robots.txt file. The rules are validated and a rule
+// allowing all the crawlers is used when none is set. For environments other than production all
+// the crawlers are disallowed.
+func NewRobotsTxtData(settings *tpltypes.RobotsData, baseURL, sitemapFile, env string) (*tpltypes.RobotsTxtData, error) {
+	data := &tpltypes.RobotsTxtData{
+		Rules:       settings.Rules,
+		DisallowAll: env != ProductionEnv,
+	}
+	if sitemapFile != "" {
+		data.SitemapURL = strings.TrimRight(baseURL, "/") + "/" + sitemapFile
+	}
+	if len(data.Rules) == 0 {
+		data.Rules = []tpltypes.RobotsRule{{UserAgents: []string{"*"}, Allow: []string{"/"}}}
+	}
+
+	for i, rule := range data.Rules {
+		if len(rule.UserAgents) == 0 {
+			return nil, fmt.Errorf("robots.rules[%d]: userAgents is required", i)
+		}
+		if rule.CrawlDelay < 0 {
+			return nil, fmt.Errorf("robots.rules[%d]: crawlDelay must be a positive number", i)
+		}
+		for _, p := range append(append([]string{}, rule.Allow...), rule.Disallow...) {
+			if p != "" && !strings.HasPrefix(p, "/") && !strings.HasPrefix(p, "*") {
+				return nil, fmt.Errorf("robots.rules[%d]: %q must start with / or *", i, p)
+			}
+		}
+	}
+	return data, nil
+}
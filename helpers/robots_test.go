@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+)
+
+func TestNewRobotsTxtData(t *testing.T) {
+	is := is.New(t)
+
+	robots, err := NewRobotsTxtData(&tpltypes.RobotsData{}, "https://example.com/", "sitemap-index.xml", ProductionEnv)
+	is.NoErr(err)
+	is.Equal(robots.SitemapURL, "https://example.com/sitemap-index.xml")
+	is.Equal(robots.Rules, []tpltypes.RobotsRule{{UserAgents: []string{"*"}, Allow: []string{"/"}}}) // default rule
+	is.True(!robots.DisallowAll)
+
+	settings := &tpltypes.RobotsData{Rules: []tpltypes.RobotsRule{{UserAgents: []string{"GPTBot"}, Disallow: []string{"/"}}}}
+	robots, err = NewRobotsTxtData(settings, "https://example.com", "", "staging")
+	is.NoErr(err)
+	is.Equal(robots.SitemapURL, "")
+	is.True(robots.DisallowAll)
+
+	tests := []tpltypes.RobotsRule{
+		{Allow: []string{"/"}},
+		{UserAgents: []string{"*"}, Disallow: []string{"drafts/"}},
+		{UserAgents: []string{"*"}, CrawlDelay: -1},
+	}
+	for _, rule := range tests {
+		_, err := NewRobotsTxtData(&tpltypes.RobotsData{Rules: []tpltypes.RobotsRule{rule}}, "https://example.com", "", ProductionEnv)
+		is.True(err != nil)
+	}
+}
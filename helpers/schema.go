@@ -0,0 +1,133 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// SchemasKey is the key for the frontmatter schemas by resource in sveltin.json.
+const SchemasKey = "schemas"
+
+// NewDefaultContentSchema returns the schema for the frontmatter set by the content templates.
+func NewDefaultContentSchema() *content.Schema {
+	return &content.Schema{
+		Fields: map[string]*content.SchemaField{
+			"layout":     {Type: content.AnyType},
+			"title":      {Type: content.StringType, Required: true},
+			"author":     {Type: content.StringType},
+			"slug":       {Type: content.StringType, Required: true, Unique: true},
+			"headline":   {Type: content.StringType},
+			"keywords":   {Type: content.ListType},
+			"created_at": {Type: content.DateType, Required: true},
+			"updated_at": {Type: content.DateType},
+			"cover":      {Type: content.StringType},
+			"draft":      {Type: content.BoolType},
+		},
+	}
+}
+
+// MetadataSchemaField returns the schema field for the metadata type (single or list).
+// The type is not checked for an unknown metadata type.
+func MetadataSchemaField(mdType string) *content.SchemaField {
+	switch mdType {
+	case "single":
+		return &content.SchemaField{Type: content.StringType}
+	case "list":
+		return &content.SchemaField{Type: content.ListType}
+	default:
+		return &content.SchemaField{Type: content.AnyType}
+	}
+}
+
+// GetContentSchemas returns the frontmatter schemas by resource set in the project settings file.
+// They are read from the file since the settings loader does not keep the case of the field names.
+func GetContentSchemas(fs afero.Fs, pathToFile string) (map[string]*content.Schema, error) {
+	data, err := afero.ReadFile(fs, pathToFile)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*content.Schema)
+	result := gjson.GetBytes(data, SchemasKey)
+	if !result.Exists() {
+		return schemas, nil
+	}
+	if err := json.Unmarshal([]byte(result.Raw), &schemas); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", pathToFile, SchemasKey, err)
+	}
+	for resource, schema := range schemas {
+		if schema == nil {
+			return nil, fmt.Errorf("%s: %s.%s: missing schema", pathToFile, SchemasKey, resource)
+		}
+		if err := schema.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %s.%s.fields.%w", pathToFile, SchemasKey, resource, err)
+		}
+	}
+	return schemas, nil
+}
+
+// AddContentSchema saves the default schema for the resource, with the fields for its metadata,
+// to the project settings file. It does nothing if the resource already has a schema.
+func AddContentSchema(fs afero.Fs, pathToFile, resource string, metadata []string) error {
+	data, err := afero.ReadFile(fs, pathToFile)
+	if err != nil {
+		return err
+	}
+	key := schemaPath(resource)
+	if gjson.GetBytes(data, key).Exists() {
+		return nil
+	}
+	schema := NewDefaultContentSchema()
+	for _, name := range metadata {
+		schema.Fields[name] = MetadataSchemaField("")
+	}
+	return writeSchemaValue(fs, pathToFile, data, key, schema)
+}
+
+// AddSchemaField saves the field to the schema for the resource in the project settings file,
+// the schema is created if missing. An existing field is not changed.
+func AddSchemaField(fs afero.Fs, pathToFile, resource, name string, field *content.SchemaField) error {
+	if err := AddContentSchema(fs, pathToFile, resource, nil); err != nil {
+		return err
+	}
+	data, err := afero.ReadFile(fs, pathToFile)
+	if err != nil {
+		return err
+	}
+	key := schemaPath(resource, "fields", name)
+	if gjson.GetBytes(data, key).Exists() {
+		return nil
+	}
+	return writeSchemaValue(fs, pathToFile, data, key, field)
+}
+
+// writeSchemaValue sets the key only, the rest of the project settings file is kept as it is.
+func writeSchemaValue(fs afero.Fs, pathToFile string, data []byte, key string, value interface{}) error {
+	data, err := sjson.SetBytes(data, key, value)
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(fs, pathToFile, data, 0644)
+}
+
+// schemaPath returns the gjson/sjson path to the schema for the resource, dots in names escaped.
+func schemaPath(resource string, keys ...string) string {
+	escaper := strings.NewReplacer(".", `\.`, "*", `\*`, "?", `\?`)
+	parts := []string{SchemasKey, escaper.Replace(resource)}
+	for _, k := range keys {
+		parts = append(parts, escaper.Replace(k))
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/content"
+)
+
+func TestContentSchemas(t *testing.T) {
+	is := is.New(t)
+
+	fs := afero.NewMemMapFs()
+	is.NoErr(afero.WriteFile(fs, "sveltin.json", []byte("{\n\t\"name\": \"site\"\n}\n"), 0644))
+
+	schemas, err := GetContentSchemas(fs, "sveltin.json")
+	is.NoErr(err)
+	is.Equal(len(schemas), 0)
+
+	is.NoErr(AddContentSchema(fs, "sveltin.json", "posts", []string{"tags"}))
+	is.NoErr(AddSchemaField(fs, "sveltin.json", "posts", "category", MetadataSchemaField("single")))
+	is.NoErr(AddSchemaField(fs, "sveltin.json", "posts", "slug", MetadataSchemaField("list"))) // existing fields not changed
+	is.NoErr(AddSchemaField(fs, "sveltin.json", "docs.v1", "topics", MetadataSchemaField("list")))
+
+	schemas, err = GetContentSchemas(fs, "sveltin.json")
+	is.NoErr(err)
+	is.Equal(len(schemas), 2)
+	posts := schemas["posts"]
+	is.Equal(posts.Fields["slug"], &content.SchemaField{Type: content.StringType, Required: true, Unique: true})
+	is.Equal(posts.Fields["tags"].Type, content.AnyType)
+	is.Equal(posts.Fields["category"].Type, content.StringType)
+	is.Equal(schemas["docs.v1"].Fields["topics"].Type, content.ListType)
+	is.Equal(len(schemas["docs.v1"].Fields), len(NewDefaultContentSchema().Fields)+1)
+
+	// the existing schema is kept
+	is.NoErr(AddContentSchema(fs, "sveltin.json", "posts", nil))
+	schemas, err = GetContentSchemas(fs, "sveltin.json")
+	is.NoErr(err)
+	is.Equal(schemas["posts"].Fields["category"].Type, content.StringType)
+
+	// only the schemas key is set
+	is.NoErr(afero.WriteFile(fs, "sveltin.json", []byte("{\n  \"name\": \"site\",\n  \"sitemap\": {\"priority\": 0.5}\n}\n"), 0644))
+	is.NoErr(AddSchemaField(fs, "sveltin.json", "posts", "category", &content.SchemaField{Type: content.StringType}))
+	data, err := afero.ReadFile(fs, "sveltin.json")
+	is.NoErr(err)
+	is.True(strings.HasPrefix(string(data), "{\n  \"name\": \"site\",\n  \"sitemap\": {\"priority\": 0.5}\n"))
+
+	is.NoErr(afero.WriteFile(fs, "sveltin.json", []byte(`{"schemas": {"posts": {"fields": {"title": {"type": "text"}}}}}`), 0644))
+	_, err = GetContentSchemas(fs, "sveltin.json")
+	is.True(err != nil)
+}
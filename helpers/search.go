@@ -0,0 +1,69 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package helpers
+
+import (
+	"strings"
+
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/internal/search"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+const (
+	// SearchIndexFilename is the name of the search index file within the static folder.
+	SearchIndexFilename = "search.json"
+	// SearchLibFilename is the name of the search helper file within the lib folder.
+	SearchLibFilename = "search.ts"
+)
+
+// NewSearchDocuments returns the documents to be indexed for the content entries, drafts are skipped.
+// The metadata values are the ones set in the frontmatter for the metadata names of the entry resource.
+func NewSearchDocuments(entries []*content.Entry, metadata map[string][]string) []*search.Document {
+	docs := []*search.Document{}
+	for _, e := range entries {
+		if e.Draft {
+			continue
+		}
+		doc := &search.Document{
+			URL:      e.URL(),
+			Title:    e.Title,
+			Headline: e.Headline,
+			Resource: e.Resource,
+			Text: map[string]string{
+				search.TitleField:    e.Title,
+				search.HeadlineField: e.Headline,
+				search.KeywordsField: strings.Join(e.Keywords, " "),
+				search.MetadataField: strings.Join(entryCategories(e, metadata[e.Resource]), " "),
+				search.BodyField:     content.StripMarkdown(e.Body),
+			},
+		}
+		if doc.Title == "" {
+			doc.Title = utils.ToTitle(e.Name)
+		}
+		if !e.CreatedAt.IsZero() {
+			doc.Date = e.CreatedAt.Format("2006-01-02")
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// SearchBoost returns the default boost by field updated with the values set.
+func SearchBoost(values ...map[string]int) (map[string]int, error) {
+	boost := search.DefaultBoost()
+	for _, v := range values {
+		if err := search.ValidateBoost(v); err != nil {
+			return nil, err
+		}
+		for field, value := range v {
+			boost[field] = value
+		}
+	}
+	return boost, nil
+}
@@ -0,0 +1,47 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/internal/search"
+)
+
+func TestNewSearchDocuments(t *testing.T) {
+	is := is.New(t)
+
+	entries := []*content.Entry{
+		{Resource: "posts", Name: "hello", Body: []byte("## Intro\n\nHello **there**"), Frontmatter: content.Frontmatter{
+			Title:     "Hello World",
+			Slug:      "hello-world",
+			Keywords:  []string{"svelte", "go"},
+			CreatedAt: time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC),
+			Extra:     map[string]interface{}{"category": "tutorials"},
+		}},
+		{Resource: "posts", Name: "draft", Frontmatter: content.Frontmatter{Draft: true}},
+		{Resource: "posts", Name: "no-title"},
+	}
+	docs := NewSearchDocuments(entries, map[string][]string{"posts": {"category"}})
+	is.Equal(len(docs), 2) // drafts skipped
+	is.Equal(docs[0].URL, "/posts/hello-world/")
+	is.Equal(docs[0].Date, "2023-01-10")
+	is.Equal(docs[0].Text[search.KeywordsField], "svelte go")
+	is.Equal(docs[0].Text[search.MetadataField], "tutorials")
+	is.Equal(docs[0].Text[search.BodyField], "Intro Hello there")
+	is.Equal(docs[1].Title, "No Title")
+}
+
+func TestSearchBoost(t *testing.T) {
+	is := is.New(t)
+
+	boost, err := SearchBoost(map[string]int{"title": 20}, nil, map[string]int{"body": 0})
+	is.NoErr(err)
+	is.Equal(boost[search.TitleField], 20)
+	is.Equal(boost[search.BodyField], 0)
+	is.Equal(boost[search.HeadlineField], 5) // default
+
+	_, err = SearchBoost(map[string]int{"summary": 1})
+	is.True(err != nil)
+}
@@ -0,0 +1,385 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package helpers
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+// sitemapFrontmatterKey is the frontmatter key used to override the sitemap settings for a content entry.
+//
+//	sitemap:
+//	  changefreq: weekly
+//	  priority: 0.8
+const sitemapFrontmatterKey = "sitemap"
+
+// translationsFrontmatterKey is the frontmatter key listing the translations of a content entry
+// by language, as entry names within the same resource or paths relative to the website root.
+//
+//	lang: en
+//	translations:
+//	  it: ciao-mondo
+//	  fr: /fr/posts/bonjour/
+const translationsFrontmatterKey = "translations"
+
+// Sitemap protocol limits for each sitemap file.
+const (
+	SitemapMaxURLs  = 50000
+	SitemapMaxBytes = 50 * 1024 * 1024
+)
+
+// sitemapPagesGroup is the group for the home page and the public pages.
+const sitemapPagesGroup = "pages"
+
+// imageExtensions are the extensions of the files listed as images of a content entry.
+var imageExtensions = []string{".avif", ".gif", ".jpeg", ".jpg", ".png", ".svg", ".webp"}
+
+// SitemapChangeFreqs is the list of the valid values for changefreq.
+var SitemapChangeFreqs = []string{"always", "hourly", "daily", "weekly", "monthly", "yearly", "never"}
+
+// SitemapSources is the struct representing the data the sitemap is built from.
+type SitemapSources struct {
+	BaseURL  string
+	Settings tpltypes.SitemapData
+	// Routes are the routes as returned by GetAllRoutes.
+	Routes    []string
+	Resources []string
+	// Metadata is the map of the metadata names by resource.
+	Metadata map[string][]string
+	Entries  []*content.Entry
+	// Language is the language of the website, used for the entries with translations and no lang set.
+	Language string
+	// Images returns the urls for the images of the content entry. No images are listed when nil.
+	Images func(e *content.Entry) []string
+	// LastModified returns the last modification time (e.g. from git or the file system) for the path
+	// to a content file or, when isRoute is true, to a route folder relative to the routes folder.
+	// It may return a zero time.
+	LastModified func(pathToFile string, isRoute bool) time.Time
+}
+
+// NewSitemapURLs returns the urls for the home page, the public pages, the resources, the published
+// content entries and the metadata routes. The errors are for not valid sitemap overrides in the
+// frontmatter, the entry default values are used for them.
+func NewSitemapURLs(src *SitemapSources) ([]*tpltypes.SitemapURL, []error) {
+	baseURL := strings.TrimRight(src.BaseURL, "/")
+	errs := []error{}
+	published := []*content.Entry{}
+	for _, e := range src.Entries {
+		if !e.Draft {
+			published = append(published, e)
+		}
+	}
+
+	newURL := func(route string, lastMod time.Time) *tpltypes.SitemapURL {
+		loc := baseURL + "/"
+		group := sitemapPagesGroup
+		if route != "" {
+			loc = baseURL + "/" + escapeRoute(route) + "/"
+			if resource := strings.Split(route, "/")[0]; common.Contains(src.Resources, resource) {
+				group = resource
+			}
+		}
+		return &tpltypes.SitemapURL{
+			Loc:        loc,
+			LastMod:    lastMod,
+			ChangeFreq: src.Settings.ChangeFreq,
+			Priority:   src.Settings.Priority,
+			Group:      group,
+		}
+	}
+	lastModified := func(pathToFile string, isRoute bool) time.Time {
+		if src.LastModified == nil {
+			return time.Time{}
+		}
+		return src.LastModified(pathToFile, isRoute)
+	}
+
+	home := newURL("", latest(published))
+	if home.LastMod.IsZero() {
+		home.LastMod = lastModified("", true)
+	}
+	urls := []*tpltypes.SitemapURL{}
+
+	for _, route := range src.Routes {
+		if route == "" || strings.Contains(route, "[") {
+			continue
+		}
+		segments := strings.Split(route, "/")
+		resource := segments[0]
+		switch {
+		case len(segments) == 1 && common.Contains(src.Resources, resource):
+			urls = append(urls, newURL(route, latest(byResource(published, resource))))
+		case len(segments) == 2 && common.Contains(src.Metadata[resource], segments[1]):
+			key := utils.ToSnakeCase(segments[1])
+			entries := content.GroupBy(byResource(published, resource), key)
+			all := []*content.Entry{}
+			for _, group := range entries {
+				all = append(all, group...)
+			}
+			urls = append(urls, newURL(route, latest(all)))
+			for value, group := range entries {
+				urls = append(urls, newURL(path.Join(route, value), latest(group)))
+			}
+		default:
+			urls = append(urls, newURL(route, lastModified(route, true)))
+		}
+	}
+
+	for _, e := range published {
+		u := newURL(path.Join(e.Resource, e.PageSlug()), e.UpdatedAt)
+		if u.LastMod.IsZero() {
+			u.LastMod = lastModified(e.Path, false)
+		}
+		if err := applySitemapOverrides(u, e); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Path, err))
+		}
+		if src.Images != nil {
+			for _, image := range src.Images(e) {
+				u.Images = append(u.Images, &tpltypes.SitemapImage{Loc: image})
+			}
+		}
+		alternates, err := entryAlternates(baseURL, u.Loc, src.Language, e)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Path, err))
+		}
+		u.Alternates = alternates
+		urls = append(urls, u)
+	}
+
+	sort.SliceStable(urls, func(i, j int) bool { return urls[i].Loc < urls[j].Loc })
+	return append([]*tpltypes.SitemapURL{home}, urls...), errs
+}
+
+// GetContentImages returns the urls for the cover of the content entry and for the images within
+// its static folder (static/resources/<resource>/<slug>), as used by the resource templates.
+func GetContentImages(fs afero.Fs, staticPath, baseURL string, e *content.Entry) []string {
+	baseURL = strings.TrimRight(baseURL, "/")
+	folder := path.Join("resources", e.Resource, e.PageSlug())
+
+	images := []string{}
+	if e.Cover != "" {
+		switch {
+		case strings.HasPrefix(e.Cover, "http://") || strings.HasPrefix(e.Cover, "https://"):
+			images = append(images, e.Cover)
+		case strings.HasPrefix(e.Cover, "/"):
+			images = append(images, baseURL+escapeRoute(e.Cover))
+		default:
+			images = append(images, baseURL+"/"+escapeRoute(path.Join(folder, e.Cover)))
+		}
+	}
+
+	files, err := afero.ReadDir(fs, filepath.Join(staticPath, folder))
+	if err != nil {
+		return images
+	}
+	for _, f := range files {
+		if f.IsDir() || !common.Contains(imageExtensions, strings.ToLower(filepath.Ext(f.Name()))) {
+			continue
+		}
+		image := baseURL + "/" + escapeRoute(path.Join(folder, f.Name()))
+		if !common.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+// SplitSitemap returns the sitemap files for the urls grouped by resource, the home page and the public
+// pages first. Groups exceeding the max number of urls or the max size are split into more files
+// (e.g. sitemap-posts.xml, sitemap-posts-2.xml).
+func SplitSitemap(baseURL string, urls []*tpltypes.SitemapURL, maxURLs, maxBytes int) []*tpltypes.SitemapFile {
+	baseURL = strings.TrimRight(baseURL, "/")
+	groups := []string{}
+	byGroup := map[string][]*tpltypes.SitemapURL{}
+	for _, u := range urls {
+		if _, ok := byGroup[u.Group]; !ok {
+			groups = append(groups, u.Group)
+		}
+		byGroup[u.Group] = append(byGroup[u.Group], u)
+	}
+
+	files := []*tpltypes.SitemapFile{}
+	for _, group := range groups {
+		chunks := [][]*tpltypes.SitemapURL{}
+		current := []*tpltypes.SitemapURL{}
+		size := sitemapOverheadBytes
+		for _, u := range byGroup[group] {
+			urlSize := estimateSitemapURLBytes(u)
+			if len(current) > 0 && (len(current) >= maxURLs || size+urlSize > maxBytes) {
+				chunks = append(chunks, current)
+				current = []*tpltypes.SitemapURL{}
+				size = sitemapOverheadBytes
+			}
+			current = append(current, u)
+			size += urlSize
+		}
+		chunks = append(chunks, current)
+
+		for i, chunk := range chunks {
+			name := "sitemap-" + utils.ToSlug(group)
+			if i > 0 {
+				name = fmt.Sprintf("%s-%d", name, i+1)
+			}
+			file := &tpltypes.SitemapFile{
+				Name: name,
+				Loc:  fmt.Sprintf("%s/%s.xml", baseURL, name),
+				URLs: chunk,
+			}
+			for _, u := range chunk {
+				if u.LastMod.After(file.LastMod) {
+					file.LastMod = u.LastMod
+				}
+			}
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// SitemapExceedsLimits returns true if a single sitemap file for the urls would exceed
+// the max number of urls or the max size.
+func SitemapExceedsLimits(urls []*tpltypes.SitemapURL, maxURLs, maxBytes int) bool {
+	if len(urls) > maxURLs {
+		return true
+	}
+	size := sitemapOverheadBytes
+	for _, u := range urls {
+		size += estimateSitemapURLBytes(u)
+	}
+	return size > maxBytes
+}
+
+//=============================================================================
+
+// sitemapOverheadBytes is the size of the xml declaration and the urlset element.
+const sitemapOverheadBytes = 512
+
+// estimateSitemapURLBytes returns the max size of the url element as rendered by the sitemap template.
+func estimateSitemapURLBytes(u *tpltypes.SitemapURL) int {
+	size := 200 + len(u.Loc)
+	for _, image := range u.Images {
+		size += 80 + len(image.Loc)
+	}
+	for _, alternate := range u.Alternates {
+		size += 80 + len(alternate.HrefLang) + len(alternate.Href)
+	}
+	return size
+}
+
+// entryAlternates returns the xhtml:link alternates for the entry translations, the entry included.
+func entryAlternates(baseURL, loc, language string, e *content.Entry) ([]*tpltypes.SitemapAlternate, error) {
+	value, ok := e.Get(translationsFrontmatterKey)
+	if !ok || value == nil {
+		return nil, nil
+	}
+	translations, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a map of languages and entries", translationsFrontmatterKey)
+	}
+
+	lang := language
+	if values := e.Values("lang"); len(values) > 0 {
+		lang = values[0]
+	}
+	alternates := []*tpltypes.SitemapAlternate{}
+	if lang != "" {
+		alternates = append(alternates, &tpltypes.SitemapAlternate{HrefLang: lang, Href: loc})
+	}
+
+	languages := make([]string, 0, len(translations))
+	for l := range translations {
+		languages = append(languages, l)
+	}
+	sort.Strings(languages)
+	for _, l := range languages {
+		target := strings.TrimSpace(fmt.Sprint(translations[l]))
+		var href string
+		switch {
+		case target == "" || l == lang:
+			continue
+		case strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://"):
+			href = target
+		case strings.HasPrefix(target, "/"):
+			href = baseURL + escapeRoute(target)
+		default:
+			href = baseURL + "/" + escapeRoute(path.Join(e.Resource, target)) + "/"
+		}
+		alternates = append(alternates, &tpltypes.SitemapAlternate{HrefLang: l, Href: href})
+	}
+	return alternates, nil
+}
+
+// applySitemapOverrides sets changefreq and priority from the frontmatter of the entry.
+func applySitemapOverrides(u *tpltypes.SitemapURL, e *content.Entry) error {
+	value, ok := e.Get(sitemapFrontmatterKey)
+	if !ok || value == nil {
+		return nil
+	}
+	overrides, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be a map with changefreq and priority", sitemapFrontmatterKey)
+	}
+
+	if v, ok := overrides["changefreq"]; ok {
+		changeFreq := fmt.Sprint(v)
+		if !common.Contains(SitemapChangeFreqs, changeFreq) {
+			return fmt.Errorf("%s.changefreq: %q is not valid (%s)", sitemapFrontmatterKey, changeFreq, strings.Join(SitemapChangeFreqs, ", "))
+		}
+		u.ChangeFreq = changeFreq
+	}
+	if v, ok := overrides["priority"]; ok {
+		priority, err := strconv.ParseFloat(fmt.Sprint(v), 32)
+		if err != nil || priority < 0 || priority > 1 {
+			return fmt.Errorf("%s.priority: %v is not a number between 0.0 and 1.0", sitemapFrontmatterKey, v)
+		}
+		u.Priority = float32(priority)
+	}
+	return nil
+}
+
+func byResource(entries []*content.Entry, resource string) []*content.Entry {
+	filtered := []*content.Entry{}
+	for _, e := range entries {
+		if e.Resource == resource {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// latest returns the most recent updated_at, or created_at when not set, of the entries.
+func latest(entries []*content.Entry) time.Time {
+	var t time.Time
+	for _, e := range entries {
+		if lastMod := e.LastModified(); lastMod.After(t) {
+			t = lastMod
+		}
+	}
+	return t
+}
+
+func escapeRoute(route string) string {
+	segments := strings.Split(route, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,135 @@
+package helpers
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/content"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+)
+
+func TestNewSitemapURLs(t *testing.T) {
+	is := is.New(t)
+
+	entries := []*content.Entry{
+		newFeedEntry("posts", "hello", "2023-01-01", false, map[string]interface{}{"tags": []interface{}{"go"}}),
+		newFeedEntry("posts", "draft", "2023-03-01", true, map[string]interface{}{"tags": []interface{}{"draft"}}),
+		newFeedEntry("posts", "custom", "2023-02-01", false, map[string]interface{}{
+			"sitemap": map[string]interface{}{"changefreq": "weekly", "priority": 0.8},
+		}),
+		newFeedEntry("posts", "wrong", "2023-02-01", false, map[string]interface{}{
+			"sitemap": map[string]interface{}{"changefreq": "sometimes"},
+		}),
+	}
+	entries[0].UpdatedAt = time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC)
+	pageTime := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	urls, errs := NewSitemapURLs(&SitemapSources{
+		BaseURL:   "https://example.com/",
+		Settings:  tpltypes.SitemapData{ChangeFreq: "monthly", Priority: 0.5},
+		Routes:    []string{"", "about", "posts", "posts/tags", "posts/[slug]"},
+		Resources: []string{"posts"},
+		Metadata:  map[string][]string{"posts": {"tags"}},
+		Entries:   entries,
+		LastModified: func(pathToFile string, isRoute bool) time.Time {
+			if isRoute && pathToFile == "about" {
+				return pageTime
+			}
+			return time.Time{}
+		},
+	})
+	is.Equal(len(errs), 1) // not valid changefreq
+
+	got := map[string]*tpltypes.SitemapURL{}
+	locs := []string{}
+	for _, u := range urls {
+		got[u.Loc] = u
+		locs = append(locs, u.Loc)
+	}
+	is.Equal(locs, []string{
+		"https://example.com/",
+		"https://example.com/about/",
+		"https://example.com/posts/",
+		"https://example.com/posts/custom/",
+		"https://example.com/posts/hello/",
+		"https://example.com/posts/tags/",
+		"https://example.com/posts/tags/go/",
+		"https://example.com/posts/wrong/",
+	})
+	is.Equal(got["https://example.com/about/"].LastMod, pageTime)
+	is.Equal(got["https://example.com/posts/hello/"].LastMod, entries[0].UpdatedAt)
+	is.Equal(got["https://example.com/posts/tags/go/"].LastMod, entries[0].UpdatedAt)
+	is.Equal(got["https://example.com/posts/"].LastMod, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC))
+	is.Equal(got["https://example.com/posts/custom/"].ChangeFreq, "weekly")
+	is.Equal(got["https://example.com/posts/custom/"].Priority, float32(0.8))
+	is.Equal(got["https://example.com/posts/wrong/"].ChangeFreq, "monthly")
+}
+
+func TestSplitSitemap(t *testing.T) {
+	is := is.New(t)
+
+	urls := []*tpltypes.SitemapURL{
+		{Loc: "https://example.com/", Group: "pages"},
+		{Loc: "https://example.com/posts/", Group: "posts", LastMod: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Loc: "https://example.com/posts/a/", Group: "posts"},
+		{Loc: "https://example.com/posts/b/", Group: "posts", LastMod: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{Loc: "https://example.com/about/", Group: "pages"},
+	}
+
+	files := SplitSitemap("https://example.com/", urls, 2, SitemapMaxBytes)
+	is.Equal(len(files), 3)
+	is.Equal(files[0].Name, "sitemap-pages")
+	is.Equal(len(files[0].URLs), 2)
+	is.Equal(files[1].Loc, "https://example.com/sitemap-posts.xml")
+	is.Equal(files[1].LastMod, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	is.Equal(files[2].Name, "sitemap-posts-2")
+	is.Equal(len(files[2].URLs), 1)
+
+	files = SplitSitemap("https://example.com", urls, SitemapMaxURLs, sitemapOverheadBytes+estimateSitemapURLBytes(urls[1]))
+	is.Equal(len(files), 5) // one url for each file
+
+	is.True(!SitemapExceedsLimits(urls, SitemapMaxURLs, SitemapMaxBytes))
+	is.True(SitemapExceedsLimits(urls, 4, SitemapMaxBytes))
+}
+
+func TestSitemapImagesAndAlternates(t *testing.T) {
+	is := is.New(t)
+
+	memFs := afero.NewMemMapFs()
+	for _, name := range []string{"cover.png", "diagram.svg", "notes.txt"} {
+		is.NoErr(afero.WriteFile(memFs, filepath.Join("static", "resources", "posts", "hello-world", name), []byte{}, 0644))
+	}
+	entry := newFeedEntry("posts", "hello", "2023-01-01", false, map[string]interface{}{
+		"lang":         "en",
+		"translations": map[string]interface{}{"it": "ciao", "fr": "/fr/posts/bonjour/"},
+	})
+	entry.Slug = "hello-world"
+	entry.Cover = "cover.png"
+
+	images := GetContentImages(memFs, "static", "https://example.com", entry)
+	is.Equal(images, []string{
+		"https://example.com/resources/posts/hello-world/cover.png",
+		"https://example.com/resources/posts/hello-world/diagram.svg",
+	})
+
+	urls, errs := NewSitemapURLs(&SitemapSources{
+		BaseURL:   "https://example.com",
+		Resources: []string{"posts"},
+		Entries:   []*content.Entry{entry},
+		Images: func(e *content.Entry) []string {
+			return GetContentImages(memFs, "static", "https://example.com", e)
+		},
+	})
+	is.Equal(len(errs), 0)
+	is.Equal(len(urls), 2)
+	is.Equal(urls[1].Loc, "https://example.com/posts/hello-world/")
+	is.Equal(urls[1].Group, "posts")
+	is.Equal(len(urls[1].Images), 2)
+	is.Equal(len(urls[1].Alternates), 3)
+	is.Equal(*urls[1].Alternates[0], tpltypes.SitemapAlternate{HrefLang: "en", Href: "https://example.com/posts/hello-world/"})
+	is.Equal(*urls[1].Alternates[1], tpltypes.SitemapAlternate{HrefLang: "fr", Href: "https://example.com/fr/posts/bonjour/"})
+	is.Equal(*urls[1].Alternates[2], tpltypes.SitemapAlternate{HrefLang: "it", Href: "https://example.com/posts/ciao/"})
+}
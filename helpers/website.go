@@ -0,0 +1,167 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package helpers
+
+import (
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/jsmod"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+)
+
+// defaultWebSiteLanguage is used when the language is not set in config/website.js.ts.
+const defaultWebSiteLanguage = "en"
+
+// GetWebSiteData returns the website metadata set in the config/website.js.ts file.
+// Values imported from sveltin.json (name and baseurl) are resolved with the project settings.
+// When the file cannot be read, the data from the project settings is returned with the error.
+func GetWebSiteData(fs afero.Fs, pathToFile string, settings *tpltypes.ProjectSettings) (*tpltypes.WebSiteData, error) {
+	identifiers := map[string]string{
+		"name":    settings.Name,
+		"baseurl": settings.BaseURL,
+	}
+	website := &tpltypes.WebSiteData{
+		Name:     settings.Name,
+		BaseURL:  settings.BaseURL,
+		Language: defaultWebSiteLanguage,
+		Title:    settings.Name,
+	}
+
+	content, err := afero.ReadFile(fs, pathToFile)
+	if err != nil {
+		return website, err
+	}
+	module, err := jsmod.ParseDeclaration(content, "website")
+	if err != nil {
+		return website, err
+	}
+
+	fields := map[string]*string{
+		"name":          &website.Name,
+		"baseURL":       &website.BaseURL,
+		"language":      &website.Language,
+		"title":         &website.Title,
+		"description":   &website.Description,
+		"copyright":     &website.Copyright,
+		"logo":          &website.Logo,
+		"contactEmail":  &website.ContactEmail,
+		"creator.name":  &website.CreatorName,
+		"creator.email": &website.CreatorEmail,
+	}
+	for path, dest := range fields {
+		src, err := module.Property(path)
+		if err != nil {
+			continue
+		}
+		if value, ok := evalStringExpression(src, identifiers); ok && value != "" {
+			*dest = value
+		}
+	}
+	if website.Description == "" {
+		if src, err := module.Property("seoDescription"); err == nil {
+			website.Description, _ = evalStringExpression(src, identifiers)
+		}
+	}
+	website.BaseURL = strings.TrimRight(website.BaseURL, "/")
+	return website, nil
+}
+
+//=============================================================================
+
+// evalStringExpression returns the value for the string expressions used in config/website.js.ts:
+// string literals, identifiers and concat calls (e.g. '2023'.concat(' - ', name)).
+func evalStringExpression(src string, identifiers map[string]string) (string, bool) {
+	src = strings.TrimSpace(src)
+	if value, ok := jsmod.Unquote(src); ok {
+		return value, true
+	}
+	if value, ok := identifiers[src]; ok {
+		return value, true
+	}
+
+	receiver, args, ok := splitConcatCall(src)
+	if !ok {
+		return "", false
+	}
+	value, ok := evalStringExpression(receiver, identifiers)
+	if !ok {
+		return "", false
+	}
+	var sb strings.Builder
+	sb.WriteString(value)
+	for _, arg := range args {
+		v, ok := evalStringExpression(arg, identifiers)
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(v)
+	}
+	return sb.String(), true
+}
+
+// splitConcatCall splits receiver.concat(a, b) into the receiver and the arguments.
+func splitConcatCall(src string) (string, []string, bool) {
+	const call = ".concat("
+	if !strings.HasSuffix(src, ")") {
+		return "", nil, false
+	}
+	idx := lastIndexOutsideQuotes(src, call)
+	if idx < 0 {
+		return "", nil, false
+	}
+	args := splitOutsideQuotes(src[idx+len(call):len(src)-1], ',')
+	return src[:idx], args, true
+}
+
+func lastIndexOutsideQuotes(s, substr string) int {
+	found := -1
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case strings.HasPrefix(s[i:], substr):
+			found = i
+		}
+	}
+	return found
+}
+
+func splitOutsideQuotes(s string, sep byte) []string {
+	parts := []string{}
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(s[start:]) != "" {
+		parts = append(parts, s[start:])
+	}
+	return parts
+}
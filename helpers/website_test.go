@@ -0,0 +1,56 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/tpltypes"
+)
+
+const websiteTS = `import { name, baseurl } from '../sveltin.json';
+import type { Sveltin } from '$sveltin';
+
+const website: Sveltin.WebSite = {
+	name: name,
+	baseURL: baseurl,
+	language: 'en-GB',
+	title: name,
+	slogan: '',
+	description: '',
+	seoDescription: "Notes about \"Go\"",
+	copyright: '2023'.concat(" - ", name),
+	keywords: [],
+	contactEmail: '',
+	creator: {
+		name: 'Jane Doe',
+		email: 'jane@example.com'
+	}
+};
+
+export { website };
+`
+
+func TestGetWebSiteData(t *testing.T) {
+	is := is.New(t)
+
+	settings := &tpltypes.ProjectSettings{Name: "my-site", BaseURL: "https://example.com/"}
+	memFs := afero.NewMemMapFs()
+	is.NoErr(afero.WriteFile(memFs, "config/website.js.ts", []byte(websiteTS), 0644))
+
+	website, err := GetWebSiteData(memFs, "config/website.js.ts", settings)
+	is.NoErr(err)
+	is.Equal(website.Name, "my-site")
+	is.Equal(website.BaseURL, "https://example.com")
+	is.Equal(website.Language, "en-GB")
+	is.Equal(website.Title, "my-site")
+	is.Equal(website.Description, `Notes about "Go"`)
+	is.Equal(website.Copyright, "2023 - my-site")
+	is.Equal(website.CreatorName, "Jane Doe")
+	is.Equal(website.CreatorEmail, "jane@example.com")
+
+	website, err = GetWebSiteData(memFs, "config/missing.js.ts", settings)
+	is.True(err != nil)
+	is.Equal(website.Title, "my-site")
+	is.Equal(website.Language, "en")
+}
@@ -66,7 +66,12 @@ func (b *ResContentBuilder) SetTemplateData(artifactData *config.TemplateData) {
 }
 
 func (b *ResContentBuilder) setFuncs() {
-	b.Funcs = template.FuncMap{
+	b.Funcs = ContentFuncs()
+}
+
+// ContentFuncs returns the functions available to the content templates and to the project archetypes.
+func ContentFuncs() template.FuncMap {
+	return template.FuncMap{
 		"ToSlug": slug.Make,
 		"ToTitle": func(txt string) string {
 			return utils.ToTitle(txt)
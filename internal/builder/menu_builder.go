@@ -8,6 +8,7 @@
 package builder
 
 import (
+	"encoding/json"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -74,6 +75,14 @@ func (b *MenuContentBuilder) setFuncs() {
 		"Sum": func(x int, y int) int {
 			return utils.Sum(x, y)
 		},
+		// Indent returns the tabs to indent a menu item at depth, children are listed within an array.
+		"Indent": func(depth int) string {
+			return strings.Repeat("\t", 2*depth-1)
+		},
+		"Quote": func(txt string) string {
+			value, _ := json.Marshal(txt)
+			return string(value)
+		},
 	}
 }
 
@@ -8,16 +8,18 @@
 package builder
 
 import (
+	"encoding/json"
 	"errors"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/sveltinio/sveltin/config"
 	sveltinerr "github.com/sveltinio/sveltin/internal/errors"
 	"github.com/sveltinio/sveltin/utils"
 )
 
-// NoPContentBuilder represents the builder for the no-page artefacts (sitemap and rss).
+// NoPContentBuilder represents the builder for the no-page artefacts (sitemap, feeds, robots.txt and search).
 type NoPContentBuilder struct {
 	ContentType       string
 	EmbeddedResources map[string]string
@@ -46,9 +48,27 @@ func (b *NoPContentBuilder) setPathToTplFile() error {
 	case "rss":
 		b.PathToTplFile = b.EmbeddedResources["rss_static"]
 		return nil
+	case "atom":
+		b.PathToTplFile = b.EmbeddedResources["atom_static"]
+		return nil
+	case "json":
+		b.PathToTplFile = b.EmbeddedResources["jsonfeed_static"]
+		return nil
 	case "sitemap":
 		b.PathToTplFile = b.EmbeddedResources["sitemap_static"]
 		return nil
+	case "sitemap-index":
+		b.PathToTplFile = b.EmbeddedResources["sitemap_index_static"]
+		return nil
+	case "robots":
+		b.PathToTplFile = b.EmbeddedResources["robots_static"]
+		return nil
+	case "search":
+		b.PathToTplFile = b.EmbeddedResources["search_static"]
+		return nil
+	case "search-lib":
+		b.PathToTplFile = b.EmbeddedResources["search_lib"]
+		return nil
 	default:
 		errN := errors.New("FileNotFound on EmbeddedFS")
 		return sveltinerr.NewDefaultError(errN)
@@ -74,6 +94,19 @@ func (b *NoPContentBuilder) setFuncs() {
 		"Trimmed": func(txt string) string {
 			return utils.Trimmed(txt)
 		},
+		"RFC822Date": func(t time.Time) string {
+			return t.Format(time.RFC1123Z)
+		},
+		"RFC3339Date": func(t time.Time) string {
+			return t.Format(time.RFC3339)
+		},
+		"ToJSON": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		"CDATA": func(txt string) string {
+			return "<![CDATA[" + strings.ReplaceAll(txt, "]]>", "]]]]><![CDATA[>") + "]]>"
+		},
 	}
 }
 
@@ -0,0 +1,284 @@
+package content
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+const blankEntry = `---
+layout: false
+title: Hello World
+author: YOUR_NAME
+slug: hello-world
+headline: Lorem ipsum dolor sit amet.
+keywords: ['sveltin', 'go']
+created_at: 2023-01-10
+updated_at: 2023-02-01
+cover:
+draft: false
+misc:
+  series: intro
+---
+
+## Heading 2 here
+
+Content text
+`
+
+func TestParseFrontmatter(t *testing.T) {
+	is := is.New(t)
+
+	fm, body, err := ParseFrontmatter("index.svx", []byte(blankEntry))
+	is.NoErr(err)
+	is.Equal(fm.Title, "Hello World")
+	is.Equal(fm.Slug, "hello-world")
+	is.Equal(fm.Author, "YOUR_NAME")
+	is.Equal(fm.Keywords, []string{"sveltin", "go"})
+	is.Equal(fm.Cover, "")
+	is.Equal(fm.Draft, false)
+	is.Equal(fm.CreatedAt, time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC))
+	is.Equal(fm.LastModified(), time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC))
+	is.Equal(fm.Extra["layout"], false)
+	is.Equal(fm.Extra["misc"], map[string]interface{}{"series": "intro"})
+	is.Equal(string(body), "\n## Heading 2 here\n\nContent text\n")
+
+	value, ok := fm.Get("misc")
+	is.True(ok)
+	is.Equal(value, map[string]interface{}{"series": "intro"})
+}
+
+func TestParseFrontmatterKeywordsString(t *testing.T) {
+	is := is.New(t)
+
+	fm, _, err := ParseFrontmatter("index.svx", []byte("---\ntitle: A\nkeywords: a, b ,c\ndraft: true\n---\n"))
+	is.NoErr(err)
+	is.Equal(fm.Keywords, []string{"a", "b", "c"})
+	is.True(fm.Draft)
+}
+
+func TestParseFrontmatterErrors(t *testing.T) {
+	is := is.New(t)
+
+	tests := []struct {
+		content string
+		line    int
+	}{
+		{content: "title: no delimiters\n", line: 1},
+		{content: "---\ntitle: not closed\n", line: 1},
+		{content: "---\ntitle: A\ndraft: maybe\n---\n", line: 3},
+		{content: "---\ntitle: A\ncreated_at: 10/01/2023\n---\n", line: 3},
+		{content: "---\ntitle: A\nslug: a: b\n---\n", line: 3},
+		{content: "---\ntitle: A\nkeywords:\n  - a: b\n---\n", line: 4},
+	}
+
+	for _, tc := range tests {
+		_, _, err := ParseFrontmatter("content/posts/a/index.svx", []byte(tc.content))
+		is.True(err != nil)
+		var parseErr *ParseError
+		is.True(errors.As(err, &parseErr))
+		is.Equal(parseErr.File, "content/posts/a/index.svx")
+		is.Equal(parseErr.Line, tc.line) // error line
+	}
+}
+
+func TestLoad(t *testing.T) {
+	is := is.New(t)
+
+	memFs := afero.NewMemMapFs()
+	files := map[string]string{
+		"content/posts/hello/index.svx":  blankEntry,
+		"content/posts/draft/index.svx":  "---\ntitle: Draft\ncreated_at: 2023-03-01\ndraft: true\n---\n",
+		"content/posts/broken/index.svx": "---\ntitle: [\n---\n",
+		"content/talks/first/index.svx":  "---\ntitle: First\n---\n",
+	}
+	for name, content := range files {
+		is.NoErr(afero.WriteFile(memFs, name, []byte(content), 0644))
+	}
+	is.NoErr(memFs.MkdirAll(filepath.Join("content", "talks", "empty"), 0755))
+
+	index, err := Load(memFs, "content", []string{"posts", "talks"})
+	is.NoErr(err)
+	is.Equal(len(index.Entries), 3)
+	is.Equal(len(index.Errors), 2) // broken frontmatter and missing index.svx
+
+	is.Equal(index.ContentMap(true), map[string][]string{"posts": {"draft", "hello"}, "talks": {"first"}})
+	is.Equal(index.ContentMap(false), map[string][]string{"posts": {"hello"}, "talks": {"first"}})
+	is.Equal(len(index.Published()), 2)
+	is.Equal(len(index.PublishedByResource("posts")), 1)
+
+	posts := index.ByResource("posts")
+	SortByDate(posts)
+	is.Equal(posts[0].Name, "draft")
+	is.Equal(posts[1].URL(), "/posts/hello-world/") // served from the slug
+	is.Equal(index.ByResource("talks")[0].URL(), "/talks/first/")
+}
+
+func TestGroupBy(t *testing.T) {
+	is := is.New(t)
+
+	entries := []*Entry{
+		{Name: "a", Frontmatter: Frontmatter{Keywords: []string{"go", "svelte"}}},
+		{Name: "b", Frontmatter: Frontmatter{Extra: map[string]interface{}{"category": "go"}}},
+		{Name: "c", Frontmatter: Frontmatter{Extra: map[string]interface{}{"category": []interface{}{"go", "go"}}}},
+	}
+	is.Equal(entries[2].Values("category"), []string{"go", "go"})
+	is.Equal(entries[0].Values("category"), []string{})
+
+	groups := GroupBy(entries, "category")
+	is.Equal(len(groups), 1)
+	is.Equal(len(groups["go"]), 2) // each entry listed once
+
+	groups = GroupBy(entries, "keywords")
+	is.Equal(len(groups["svelte"]), 1)
+}
+
+func TestStripMarkdown(t *testing.T) {
+	is := is.New(t)
+
+	body := []byte(`<script>
+	import Card from '$lib/Card.svelte';
+</script>
+
+## Getting *started*
+
+> Read the [docs](https://sveltin.io) first.
+
+- one
+- two ![logo](logo.png)
+
+` + "```go\nfmt.Println(\"skipped\")\n```" + `
+
+{#if done}<Card title="x" />{/if}
+`)
+	is.Equal(StripMarkdown(body), "Getting started Read the docs first. one two logo")
+}
+
+const enrichEntry = `---
+title: Hello   # keep the comment
+keywords: [a, b]
+sveltin:
+  word_count: 1
+---
+<script>
+	import Card from '$lib/Card.svelte';
+</script>
+
+# Hello World
+
+Some text here, don't *skip* it.
+
+` + "```sh\n# not a heading\n```" + `
+
+## Setup [guide](/guide) ##
+
+### Setup
+
+#### Too deep
+`
+
+func TestComputeStats(t *testing.T) {
+	is := is.New(t)
+
+	_, body, err := ParseFrontmatter("index.svx", []byte(enrichEntry))
+	is.NoErr(err)
+
+	stats := ComputeStats(body, 200, DefaultTOCDepth)
+	is.Equal(stats.WordCount, 13)
+	is.Equal(stats.ReadingTime, 1)
+	is.Equal(stats.TOC, []Heading{
+		{Level: 1, Text: "Hello World", ID: "hello-world"},
+		{Level: 2, Text: "Setup guide", ID: "setup-guide"},
+		{Level: 3, Text: "Setup", ID: "setup"},
+	})
+
+	stats = ComputeStats([]byte("one two three"), 2, DefaultTOCDepth)
+	is.Equal(stats.ReadingTime, 2)
+	is.Equal(uniqueSlug("Setup", map[string]int{"setup": 0}), "setup-1")
+}
+
+func TestEnrich(t *testing.T) {
+	is := is.New(t)
+
+	fm, body, err := ParseFrontmatter("index.svx", []byte(enrichEntry))
+	is.NoErr(err)
+	stats := ComputeStats(body, DefaultWordsPerMinute, 2)
+
+	out, err := Enrich([]byte(enrichEntry), stats)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(string(out), "---\ntitle: Hello   # keep the comment\nkeywords: [a, b]\nsveltin:\n  word_count: 13\n  reading_time: 1\n  toc:\n"))
+
+	enriched, newBody, err := ParseFrontmatter("index.svx", out)
+	is.NoErr(err)
+	is.Equal(newBody, body) // body untouched
+	is.Equal(enriched.Title, fm.Title)
+	value, ok := enriched.Get(Namespace)
+	is.True(ok)
+	is.Equal(value.(map[string]interface{})["word_count"], 13)
+
+	again, err := Enrich(out, stats)
+	is.NoErr(err)
+	is.Equal(string(again), string(out)) // idempotent
+
+	// appended when missing, CRLF line endings kept
+	out, err = Enrich([]byte("---\r\ntitle: A\r\n---\r\nbody\r\n"), &Stats{TOC: []Heading{}})
+	is.NoErr(err)
+	is.Equal(string(out), "---\r\ntitle: A\r\nsveltin:\r\n  word_count: 0\r\n  reading_time: 0\r\n  toc: []\r\n---\r\nbody\r\n")
+
+	_, err = Enrich([]byte("no frontmatter"), stats)
+	is.True(errors.Is(err, ErrNoFrontmatter))
+}
+
+func TestLinter(t *testing.T) {
+	is := is.New(t)
+
+	schema := &Schema{Fields: map[string]*SchemaField{
+		"title":      {Type: StringType, Required: true},
+		"slug":       {Type: StringType, Required: true, Unique: true},
+		"created_at": {Type: DateType, Required: true, Format: DateFormat},
+		"draft":      {Type: BoolType},
+		"category":   {Type: StringType, Enum: []string{"news", "tutorials"}},
+		"tags":       {Type: ListType, Enum: []string{"go", "svelte"}},
+	}}
+	is.NoErr(schema.Validate())
+
+	linter := NewLinter(schema)
+	messages := func(diags []*Diagnostic) []string {
+		list := []string{}
+		for _, d := range diags {
+			list = append(list, d.String())
+		}
+		return list
+	}
+
+	is.Equal(messages(linter.Lint("a.svx", []byte("---\ntitle: A\nslug: a\ncreated_at: 2023-01-02\ntags: [go]\nsveltin:\n  word_count: 1\n---\nBody\n"))), []string{})
+	is.Equal(messages(linter.Lint("b.svx", []byte("---\ntitle:\nslug: a\ncraeted_at: 2023-01-02T10:00:00Z\ndraft: \"no\"\ncategory: misc\ntags: svelte\n---\n"))), []string{
+		"b.svx:1: created_at: required field is missing",
+		"b.svx:2: title: required field is empty",
+		"b.svx:3: slug: \"a\" is already used by a.svx",
+		"b.svx:4: craeted_at: unknown field, did you mean \"created_at\"?",
+		"b.svx:5: draft: must be true or false, got a string",
+		"b.svx:6: category: \"misc\" is not allowed, allowed values are: news, tutorials",
+		"b.svx:7: tags: must be a list, got a string",
+	})
+	is.Equal(messages(linter.Lint("c.svx", []byte("---\ntitle: C\nslug: c\ncreated_at: 2023-01-02T10:00:00Z\ntags: [go, rust]\n---\n"))), []string{
+		"c.svx:4: created_at: \"2023-01-02T10:00:00Z\" does not match the date format (2006-01-02)",
+		"c.svx:5: tags: \"rust\" is not allowed, allowed values are: go, svelte",
+	})
+
+	d := linter.Lint("d.svx", []byte("---\ntitle: D\nslug: d\ncreated_at: 2023-01-02\nother: x\n---\n"))
+	is.Equal(len(d), 1)
+	is.True(d[0].Warning) // unknown keys are warnings
+
+	// with no schema only the frontmatter syntax is checked
+	is.Equal(messages(NewLinter(nil).Lint("e.svx", []byte("---\ndraft: maybe\n---\n"))), []string{"e.svx:2: draft: must be true or false"})
+	is.Equal(messages(NewLinter(nil).Lint("f.svx", []byte("no frontmatter"))), []string{"f.svx:1: no frontmatter found"})
+
+	is.True((&Schema{Fields: map[string]*SchemaField{"x": {Type: "text"}}}).Validate() != nil)
+	is.True((&Schema{Fields: map[string]*SchemaField{"x": {Type: StringType, Format: DateFormat}}}).Validate() != nil)
+}
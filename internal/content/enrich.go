@@ -0,0 +1,181 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package content
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Namespace is the frontmatter key holding the values computed by sveltin.
+const Namespace = "sveltin"
+
+// DefaultWordsPerMinute is the reading speed used for the reading time.
+const DefaultWordsPerMinute = 200
+
+// DefaultTOCDepth is the deepest heading level listed in the table of contents.
+const DefaultTOCDepth = 3
+
+var (
+	atxHeadingRegexp = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+	codeFenceRegexp  = regexp.MustCompile("^ {0,3}(```|~~~)")
+	namespaceRegexp  = regexp.MustCompile(`^` + Namespace + `[ \t]*:`)
+)
+
+// Heading is the struct representing a heading within the table of contents.
+type Heading struct {
+	Level int    `yaml:"level"`
+	Text  string `yaml:"text"`
+	// ID is the anchor for the heading, as generated by rehype-slug (github-slugger).
+	ID string `yaml:"id"`
+}
+
+// Stats is the struct representing the values computed for the body of a content entry.
+type Stats struct {
+	WordCount int `yaml:"word_count"`
+	// ReadingTime is the reading time in minutes.
+	ReadingTime int       `yaml:"reading_time"`
+	TOC         []Heading `yaml:"toc"`
+}
+
+// ComputeStats returns word count, reading time and the headings up to maxDepth for the markdown body.
+func ComputeStats(body []byte, wordsPerMinute, maxDepth int) *Stats {
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+	stats := &Stats{TOC: []Heading{}}
+	stats.WordCount = len(strings.FieldsFunc(StripMarkdown(body), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	}))
+	if stats.WordCount > 0 {
+		stats.ReadingTime = int(math.Ceil(float64(stats.WordCount) / float64(wordsPerMinute)))
+	}
+
+	slugs := make(map[string]int)
+	inFence := ""
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := codeFenceRegexp.FindStringSubmatch(line); m != nil {
+			switch inFence {
+			case "":
+				inFence = m[1]
+			case m[1]:
+				inFence = ""
+			}
+			continue
+		}
+		if inFence != "" {
+			continue
+		}
+		m := atxHeadingRegexp.FindStringSubmatch(line)
+		if m == nil || len(m[1]) > maxDepth {
+			continue
+		}
+		text := StripMarkdown([]byte(m[2]))
+		if text == "" {
+			continue
+		}
+		stats.TOC = append(stats.TOC, Heading{Level: len(m[1]), Text: text, ID: uniqueSlug(text, slugs)})
+	}
+	return stats
+}
+
+// Enrich sets the stats under the namespace key of the frontmatter. The existing namespace block is
+// replaced in place, otherwise it is appended. The other frontmatter lines and the body are not changed,
+// so enriching twice returns the same content.
+func Enrich(content []byte, stats *Stats) ([]byte, error) {
+	start, end, _, err := FrontmatterBounds(content)
+	if err != nil {
+		return nil, err
+	}
+	raw := string(content[start:end])
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(map[string]*Stats{Namespace: stats}); err != nil {
+		return nil, err
+	}
+	block := buf.String()
+	if strings.Contains(raw, "\r\n") {
+		block = strings.ReplaceAll(block, "\n", "\r\n")
+	}
+
+	lines := strings.SplitAfter(raw, "\n")
+	from, to := -1, -1
+	for i, line := range lines {
+		if from < 0 {
+			if namespaceRegexp.MatchString(line) {
+				from, to = i, i+1
+			}
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' && !strings.HasPrefix(line, "- ") {
+			break
+		}
+		to = i + 1
+	}
+
+	var fm string
+	if from < 0 {
+		fm = raw
+		if fm != "" && !strings.HasSuffix(fm, "\n") {
+			fm += "\n"
+		}
+		fm += block
+	} else {
+		fm = strings.Join(lines[:from], "") + block + strings.Join(lines[to:], "")
+	}
+
+	out := make([]byte, 0, len(content)+len(block))
+	out = append(out, content[:start]...)
+	out = append(out, fm...)
+	out = append(out, content[end:]...)
+	return out, nil
+}
+
+// uniqueSlug returns the github-slugger slug for the text, adding -1, -2 etc. when already used.
+func uniqueSlug(text string, slugs map[string]int) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == ' ':
+			sb.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+		}
+	}
+	slug := sb.String()
+	id := slug
+	for {
+		n, ok := slugs[id]
+		if !ok {
+			break
+		}
+		slugs[id] = n + 1
+		id = slug + "-" + strconv.Itoa(n+1)
+	}
+	slugs[id] = 0
+	return id
+}
+
+// String returns the stats as shown in the command output.
+func (s *Stats) String() string {
+	return fmt.Sprintf("%d words, %d min, %d headings", s.WordCount, s.ReadingTime, len(s.TOC))
+}
@@ -0,0 +1,334 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+// Package content reads the content entries of a sveltin project (content/<resource>/<entry>/index.svx)
+// and parses their YAML frontmatter into typed records shared by the generators and listing commands.
+package content
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const delimiter = "---"
+
+// ErrNoFrontmatter is returned when the content file does not start with a frontmatter block.
+var ErrNoFrontmatter = errors.New("no frontmatter found")
+
+// dateLayouts are the layouts accepted for the created_at and updated_at fields.
+var dateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	// set by the content templates (utils.Today).
+	"02-Jan-2006",
+}
+
+// Frontmatter is the struct representing the frontmatter fields set by the content templates
+// and listed as YAMLFrontmatter in src/sveltin.d.ts.
+type Frontmatter struct {
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
+	Author    string    `json:"author,omitempty"`
+	Headline  string    `json:"headline,omitempty"`
+	Keywords  []string  `json:"keywords,omitempty"`
+	Cover     string    `json:"cover,omitempty"`
+	Draft     bool      `json:"draft"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Extra holds the keys with no typed field (e.g. layout, misc).
+	Extra map[string]interface{} `json:"extra,omitempty"`
+}
+
+// Get returns the value for the key, looking at the typed fields first.
+func (fm *Frontmatter) Get(key string) (interface{}, bool) {
+	switch key {
+	case "title":
+		return fm.Title, true
+	case "slug":
+		return fm.Slug, true
+	case "author":
+		return fm.Author, fm.Author != ""
+	case "headline":
+		return fm.Headline, fm.Headline != ""
+	case "keywords":
+		return fm.Keywords, len(fm.Keywords) > 0
+	case "cover":
+		return fm.Cover, fm.Cover != ""
+	case "draft":
+		return fm.Draft, true
+	case "created_at":
+		return fm.CreatedAt, !fm.CreatedAt.IsZero()
+	case "updated_at":
+		return fm.UpdatedAt, !fm.UpdatedAt.IsZero()
+	}
+	value, ok := fm.Extra[key]
+	return value, ok
+}
+
+// Values returns the value for the key as a list of strings (e.g. the values for a metadata).
+func (fm *Frontmatter) Values(key string) []string {
+	value, ok := fm.Get(key)
+	if !ok {
+		return []string{}
+	}
+	return stringValues(value)
+}
+
+// LastModified returns updated_at if set, created_at otherwise.
+func (fm *Frontmatter) LastModified() time.Time {
+	if !fm.UpdatedAt.IsZero() {
+		return fm.UpdatedAt
+	}
+	return fm.CreatedAt
+}
+
+// ParseError is the error returned when the frontmatter of a content file is not valid.
+type ParseError struct {
+	File string
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.File, e.Err)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// ParseFrontmatter parses the frontmatter of the content file and returns it with the body.
+// Errors are of type *ParseError reporting the line within the file.
+func ParseFrontmatter(file string, content []byte) (*Frontmatter, []byte, error) {
+	raw, body, offset, err := splitFrontmatter(content)
+	if err != nil {
+		return nil, nil, &ParseError{File: file, Line: 1, Err: err}
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, nil, &ParseError{File: file, Line: yamlErrorLine(err, offset), Err: cleanYAMLError(err)}
+	}
+
+	fm := &Frontmatter{Extra: map[string]interface{}{}}
+	if len(doc.Content) == 0 {
+		return fm, body, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, &ParseError{File: file, Line: root.Line + offset, Err: errors.New("frontmatter must be a mapping of keys and values")}
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if err := fm.set(key.Value, value); err != nil {
+			return nil, nil, &ParseError{File: file, Line: value.Line + offset, Err: fmt.Errorf("%s: %w", key.Value, err)}
+		}
+	}
+	return fm, body, nil
+}
+
+func (fm *Frontmatter) set(key string, value *yaml.Node) error {
+	switch key {
+	case "title":
+		return decodeString(value, &fm.Title)
+	case "slug":
+		return decodeString(value, &fm.Slug)
+	case "author":
+		return decodeString(value, &fm.Author)
+	case "headline":
+		return decodeString(value, &fm.Headline)
+	case "cover":
+		return decodeString(value, &fm.Cover)
+	case "keywords":
+		return decodeKeywords(value, &fm.Keywords)
+	case "draft":
+		return decodeBool(value, &fm.Draft)
+	case "created_at":
+		return decodeDate(value, &fm.CreatedAt)
+	case "updated_at":
+		return decodeDate(value, &fm.UpdatedAt)
+	}
+
+	var v interface{}
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	fm.Extra[key] = v
+	return nil
+}
+
+//=============================================================================
+
+func stringValues(value interface{}) []string {
+	values := []string{}
+	switch v := value.(type) {
+	case nil:
+	case string:
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	case []string:
+		for _, s := range v {
+			values = append(values, stringValues(s)...)
+		}
+	case []interface{}:
+		for _, s := range v {
+			values = append(values, stringValues(s)...)
+		}
+	case time.Time:
+		values = append(values, v.Format("2006-01-02"))
+	default:
+		values = append(values, fmt.Sprint(v))
+	}
+	return values
+}
+
+// splitFrontmatter returns the frontmatter and the body of the content file and
+// the number of lines before the frontmatter.
+func splitFrontmatter(content []byte) ([]byte, []byte, int, error) {
+	start, end, bodyStart, err := FrontmatterBounds(content)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return content[start:end], content[bodyStart:], 1, nil
+}
+
+// FrontmatterBounds returns the offsets of the frontmatter (content[start:end]) and of the body
+// within the content file, a leading byte order mark is skipped. content[:start] is the opening
+// delimiter line and content[end:bodyStart] the closing one. ErrNoFrontmatter is returned when
+// the content does not start with a delimiter line.
+func FrontmatterBounds(content []byte) (int, int, int, error) {
+	bom := 0
+	if bytes.HasPrefix(content, []byte("\xef\xbb\xbf")) {
+		bom = 3
+	}
+	if !bytes.HasPrefix(content[bom:], []byte(delimiter)) {
+		return 0, 0, 0, ErrNoFrontmatter
+	}
+	start := bytes.IndexByte(content[bom:], '\n')
+	if start < 0 || strings.TrimSpace(string(content[bom:bom+start])) != delimiter {
+		return 0, 0, 0, ErrNoFrontmatter
+	}
+	start += bom + 1
+
+	pos := start
+	for pos <= len(content) {
+		end := bytes.IndexByte(content[pos:], '\n')
+		line := content[pos:]
+		if end >= 0 {
+			line = content[pos : pos+end]
+		}
+		if strings.TrimRight(string(line), " \t\r") == delimiter {
+			if end < 0 {
+				return start, pos, len(content), nil
+			}
+			return start, pos, pos + end + 1, nil
+		}
+		if end < 0 {
+			break
+		}
+		pos += end + 1
+	}
+	return 0, 0, 0, errors.New("frontmatter closing delimiter not found")
+}
+
+var yamlLineRegexp = regexp.MustCompile(`line (\d+)`)
+
+func yamlErrorLine(err error, offset int) int {
+	if m := yamlLineRegexp.FindStringSubmatch(err.Error()); m != nil {
+		if n, convErr := strconv.Atoi(m[1]); convErr == nil {
+			return n + offset
+		}
+	}
+	return 0
+}
+
+func cleanYAMLError(err error) error {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	msg = yamlLineRegexp.ReplaceAllString(msg, "")
+	return errors.New(strings.TrimLeft(msg, ": "))
+}
+
+func decodeString(value *yaml.Node, dest *string) error {
+	if value.Kind != yaml.ScalarNode {
+		return errors.New("must be a string")
+	}
+	if value.Tag == "!!null" {
+		*dest = ""
+		return nil
+	}
+	*dest = value.Value
+	return nil
+}
+
+func decodeBool(value *yaml.Node, dest *bool) error {
+	if value.Tag == "!!null" {
+		*dest = false
+		return nil
+	}
+	if err := value.Decode(dest); err != nil {
+		return errors.New("must be true or false")
+	}
+	return nil
+}
+
+// decodeKeywords accepts both a list and a comma separated string.
+func decodeKeywords(value *yaml.Node, dest *[]string) error {
+	switch {
+	case value.Tag == "!!null":
+		*dest = nil
+	case value.Kind == yaml.SequenceNode:
+		keywords := []string{}
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode {
+				return errors.New("must be a list of strings")
+			}
+			keywords = append(keywords, item.Value)
+		}
+		*dest = keywords
+	case value.Kind == yaml.ScalarNode:
+		keywords := []string{}
+		for _, k := range strings.Split(value.Value, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				keywords = append(keywords, k)
+			}
+		}
+		*dest = keywords
+	default:
+		return errors.New("must be a list of strings")
+	}
+	return nil
+}
+
+func decodeDate(value *yaml.Node, dest *time.Time) error {
+	if value.Tag == "!!null" || value.Value == "" {
+		*dest = time.Time{}
+		return nil
+	}
+	if value.Kind != yaml.ScalarNode {
+		return errors.New("must be a date")
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value.Value); err == nil {
+			*dest = t
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not a valid date (use YYYY-MM-DD)", value.Value)
+}
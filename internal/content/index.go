@@ -0,0 +1,174 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package content
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+)
+
+// IndexFile is the name of the file holding the content entry.
+const IndexFile = "index.svx"
+
+// Entry is the struct representing a content entry (content/<resource>/<name>/index.svx).
+type Entry struct {
+	Resource string `json:"resource"`
+	// Name is the name of the entry folder.
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Frontmatter
+	Body []byte `json:"-"`
+}
+
+// Index is the struct representing the content entries of a project.
+type Index struct {
+	Entries []*Entry
+	// Errors holds the errors for the entries that could not be parsed and are not listed in Entries.
+	Errors []error
+}
+
+// Load reads the content entries for the resources within the content folder.
+// Entries are sorted by resource and name. Invalid entries are reported in Index.Errors.
+func Load(fs afero.Fs, contentPath string, resources []string) (*Index, error) {
+	index := &Index{Entries: []*Entry{}, Errors: []error{}}
+
+	exists, err := afero.DirExists(fs, contentPath)
+	if err != nil || !exists {
+		return index, err
+	}
+
+	for _, resource := range resources {
+		resourcePath := filepath.Join(contentPath, resource)
+		folders, err := afero.ReadDir(fs, resourcePath)
+		if err != nil {
+			continue
+		}
+		for _, folder := range folders {
+			if !folder.IsDir() {
+				continue
+			}
+			pathToFile := filepath.Join(resourcePath, folder.Name(), IndexFile)
+			entry, err := LoadEntry(fs, pathToFile)
+			if err != nil {
+				index.Errors = append(index.Errors, err)
+				continue
+			}
+			entry.Resource = resource
+			entry.Name = folder.Name()
+			index.Entries = append(index.Entries, entry)
+		}
+	}
+
+	sort.SliceStable(index.Entries, func(i, j int) bool {
+		if index.Entries[i].Resource != index.Entries[j].Resource {
+			return index.Entries[i].Resource < index.Entries[j].Resource
+		}
+		return index.Entries[i].Name < index.Entries[j].Name
+	})
+	return index, nil
+}
+
+// LoadEntry reads and parses the content file.
+func LoadEntry(fs afero.Fs, pathToFile string) (*Entry, error) {
+	content, err := afero.ReadFile(fs, pathToFile)
+	if err != nil {
+		return nil, &ParseError{File: pathToFile, Err: fmt.Errorf("%s not found", IndexFile)}
+	}
+	fm, body, err := ParseFrontmatter(pathToFile, content)
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{
+		Resource:    filepath.Base(filepath.Dir(filepath.Dir(pathToFile))),
+		Name:        filepath.Base(filepath.Dir(pathToFile)),
+		Path:        pathToFile,
+		Frontmatter: *fm,
+		Body:        body,
+	}, nil
+}
+
+// Filter returns the entries for which keep returns true.
+func (idx *Index) Filter(keep func(*Entry) bool) []*Entry {
+	entries := []*Entry{}
+	for _, e := range idx.Entries {
+		if keep(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// ByResource returns the entries for the resource.
+func (idx *Index) ByResource(resource string) []*Entry {
+	return idx.Filter(func(e *Entry) bool { return e.Resource == resource })
+}
+
+// Published returns the entries not marked as draft.
+func (idx *Index) Published() []*Entry {
+	return idx.Filter(func(e *Entry) bool { return !e.Draft })
+}
+
+// PublishedByResource returns the entries for the resource not marked as draft.
+func (idx *Index) PublishedByResource(resource string) []*Entry {
+	return idx.Filter(func(e *Entry) bool { return e.Resource == resource && !e.Draft })
+}
+
+// ContentMap returns the entry names by resource, as helpers.GetResourceContentMap does.
+func (idx *Index) ContentMap(includeDrafts bool) map[string][]string {
+	contents := make(map[string][]string)
+	for _, e := range idx.Entries {
+		if e.Draft && !includeDrafts {
+			continue
+		}
+		contents[e.Resource] = append(contents[e.Resource], e.Name)
+	}
+	return contents
+}
+
+// GroupBy returns the entries by the values set for the frontmatter key.
+// Entries with more values (e.g. tags: [a, b]) are listed for each of them.
+func GroupBy(entries []*Entry, key string) map[string][]*Entry {
+	groups := make(map[string][]*Entry)
+	for _, e := range entries {
+		for _, value := range e.Values(key) {
+			if n := len(groups[value]); n > 0 && groups[value][n-1] == e {
+				continue
+			}
+			groups[value] = append(groups[value], e)
+		}
+	}
+	return groups
+}
+
+// SortByDate sorts the entries by created_at, newest first. Entries with no date come last.
+func SortByDate(entries []*Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		ti, tj := entries[i].CreatedAt, entries[j].CreatedAt
+		if ti.IsZero() != tj.IsZero() {
+			return !ti.IsZero()
+		}
+		return ti.After(tj)
+	})
+}
+
+// PageSlug returns the slug the entry page is served from: the frontmatter slug or,
+// when not set, the entry folder name.
+func (e *Entry) PageSlug() string {
+	if e.Slug != "" {
+		return e.Slug
+	}
+	return e.Name
+}
+
+// URL returns the path to the entry page relative to the website root, with a trailing slash.
+func (e *Entry) URL() string {
+	return fmt.Sprintf("/%s/%s/", e.Resource, e.PageSlug())
+}
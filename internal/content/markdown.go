@@ -0,0 +1,50 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package content
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	fencedCodeRegexp    = regexp.MustCompile("(?ms)^\\s*(```|~~~).*?^\\s*(```|~~~)\\s*$")
+	scriptOrStyleRegexp = regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(script|style)>`)
+	htmlCommentRegexp   = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlTagRegexp       = regexp.MustCompile(`<[^>]+>`)
+	svelteBlockRegexp   = regexp.MustCompile(`\{[#:/@][^}]*\}`)
+	imageRegexp         = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkRegexp          = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	linkDefRegexp       = regexp.MustCompile(`(?m)^\s*\[[^\]]+\]:\s*\S+.*$`)
+	headingRegexp       = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s+`)
+	blockquoteRegexp    = regexp.MustCompile(`(?m)^\s*>+\s?`)
+	listMarkerRegexp    = regexp.MustCompile(`(?m)^\s*([-*+]|\d+[.)])\s+`)
+	ruleRegexp          = regexp.MustCompile(`(?m)^\s*([-*_]\s*){3,}$`)
+	markupRegexp        = regexp.MustCompile("[*_~`|]+")
+	spacesRegexp        = regexp.MustCompile(`\s+`)
+)
+
+// StripMarkdown returns the plain text for the markdown (mdsvex) content: code blocks, html,
+// svelte blocks and markup are removed, links and images are replaced by their text.
+func StripMarkdown(body []byte) string {
+	text := string(body)
+	text = fencedCodeRegexp.ReplaceAllString(text, " ")
+	text = scriptOrStyleRegexp.ReplaceAllString(text, " ")
+	text = htmlCommentRegexp.ReplaceAllString(text, " ")
+	text = htmlTagRegexp.ReplaceAllString(text, " ")
+	text = svelteBlockRegexp.ReplaceAllString(text, " ")
+	text = imageRegexp.ReplaceAllString(text, "$1")
+	text = linkRegexp.ReplaceAllString(text, "$1")
+	text = linkDefRegexp.ReplaceAllString(text, " ")
+	text = ruleRegexp.ReplaceAllString(text, " ")
+	text = headingRegexp.ReplaceAllString(text, "")
+	text = blockquoteRegexp.ReplaceAllString(text, "")
+	text = listMarkerRegexp.ReplaceAllString(text, "")
+	text = markupRegexp.ReplaceAllString(text, " ")
+	return strings.TrimSpace(spacesRegexp.ReplaceAllString(text, " "))
+}
@@ -0,0 +1,362 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package content
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sveltinio/sveltin/common"
+	"gopkg.in/yaml.v3"
+)
+
+// Field types for the frontmatter schemas.
+const (
+	AnyType    string = "any"
+	StringType string = "string"
+	NumberType string = "number"
+	BoolType   string = "bool"
+	DateType   string = "date"
+	ListType   string = "list"
+	ObjectType string = "object"
+)
+
+// FieldTypes is the list of the supported field types.
+var FieldTypes = []string{AnyType, StringType, NumberType, BoolType, DateType, ListType, ObjectType}
+
+// Date formats for the date fields.
+const (
+	// DateFormat is YYYY-MM-DD.
+	DateFormat string = "date"
+	// DateTimeFormat is RFC 3339 (e.g. 2023-01-02T15:04:05Z).
+	DateTimeFormat string = "datetime"
+)
+
+var dateFormatLayouts = map[string]string{
+	DateFormat:     "2006-01-02",
+	DateTimeFormat: time.RFC3339,
+}
+
+// Schema is the struct representing the frontmatter schema for the content entries of a resource.
+type Schema struct {
+	Fields map[string]*SchemaField `json:"fields"`
+	// AllowUnknown disables the warnings for the frontmatter keys not listed in Fields.
+	AllowUnknown bool `json:"allowUnknown,omitempty"`
+}
+
+// SchemaField is the struct representing the rules for a frontmatter key.
+type SchemaField struct {
+	Type     string `json:"type"`
+	Required bool   `json:"required,omitempty"`
+	// Enum is the list of the allowed values, for the list items too.
+	Enum []string `json:"enum,omitempty"`
+	// Unique requires a value not used by other entries of the resource.
+	Unique bool `json:"unique,omitempty"`
+	// Format is the format for the date fields (date or datetime), any date accepted by sveltin when empty.
+	Format string `json:"format,omitempty"`
+}
+
+// Validate returns an error if a field has a not valid type or format.
+func (s *Schema) Validate() error {
+	for _, name := range s.fieldNames() {
+		f := s.Fields[name]
+		if f == nil {
+			return fmt.Errorf("%s: missing field rules", name)
+		}
+		if !common.Contains(FieldTypes, f.Type) {
+			return fmt.Errorf("%s: %q is not a valid type, valid types are: %s", name, f.Type, strings.Join(FieldTypes, ", "))
+		}
+		if f.Format != "" {
+			if f.Type != DateType {
+				return fmt.Errorf("%s: format is for date fields only", name)
+			}
+			if _, ok := dateFormatLayouts[f.Format]; !ok {
+				return fmt.Errorf("%s: %q is not a valid format, valid formats are: %s, %s", name, f.Format, DateFormat, DateTimeFormat)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) fieldNames() []string {
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//=============================================================================
+
+// Diagnostic is the struct representing a problem found when linting a content file.
+type Diagnostic struct {
+	File string
+	Line int
+	// Field is the frontmatter key, empty for the whole file.
+	Field   string
+	Message string
+	// Warning is true when the problem does not break the build (e.g. an unknown key).
+	Warning bool
+}
+
+func (d *Diagnostic) String() string {
+	location := d.File
+	if d.Line > 0 {
+		location = fmt.Sprintf("%s:%d", d.File, d.Line)
+	}
+	if d.Field != "" {
+		return fmt.Sprintf("%s: %s: %s", location, d.Field, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", location, d.Message)
+}
+
+// Linter validates the content files of a resource against its schema. It keeps the values of
+// the unique fields, so the files of a resource must be linted with the same Linter.
+type Linter struct {
+	schema *Schema
+	// seen is the file using each value by unique field.
+	seen map[string]map[string]string
+}
+
+// NewLinter returns a Linter for the schema. With a nil schema only the frontmatter syntax and
+// the fields known to sveltin (e.g. draft, created_at) are checked.
+func NewLinter(schema *Schema) *Linter {
+	return &Linter{schema: schema, seen: make(map[string]map[string]string)}
+}
+
+// Lint returns the problems found in the content file, sorted by line.
+func (l *Linter) Lint(file string, content []byte) []*Diagnostic {
+	var parseDiag *Diagnostic
+	if _, _, err := ParseFrontmatter(file, content); err != nil {
+		parseDiag = &Diagnostic{File: file, Message: err.Error()}
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			parseDiag.Line, parseDiag.Message = parseErr.Line, parseErr.Err.Error()
+		}
+	}
+	diags := l.lintSchema(file, content)
+	if parseDiag != nil {
+		// the schema rules already report the not valid values with a clearer message.
+		duplicate := false
+		for _, d := range diags {
+			if d.Line == parseDiag.Line && !d.Warning {
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			diags = append(diags, parseDiag)
+		}
+	}
+	sort.SliceStable(diags, func(i, j int) bool { return diags[i].Line < diags[j].Line })
+	return diags
+}
+
+func (l *Linter) lintSchema(file string, content []byte) []*Diagnostic {
+	diags := []*Diagnostic{}
+	if l.schema == nil {
+		return diags
+	}
+	raw, _, offset, err := splitFrontmatter(content)
+	if err != nil {
+		return diags
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		// reported by ParseFrontmatter, an empty frontmatter misses the required fields only.
+		if err != nil || len(doc.Content) > 0 {
+			return diags
+		}
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+	}
+	root := doc.Content[0]
+
+	keys := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		keys[key.Value] = value
+		line := key.Line + offset
+		f, ok := l.schema.Fields[key.Value]
+		if !ok {
+			if !l.schema.AllowUnknown && key.Value != Namespace {
+				diags = append(diags, &Diagnostic{File: file, Line: line, Field: key.Value, Message: l.unknownFieldMessage(key.Value), Warning: true})
+			}
+			continue
+		}
+		for _, msg := range checkField(f, value) {
+			diags = append(diags, &Diagnostic{File: file, Line: line, Field: key.Value, Message: msg})
+		}
+		if f.Unique && value.Kind == yaml.ScalarNode && value.Tag != "!!null" {
+			if l.seen[key.Value] == nil {
+				l.seen[key.Value] = make(map[string]string)
+			}
+			if other, ok := l.seen[key.Value][value.Value]; ok {
+				diags = append(diags, &Diagnostic{File: file, Line: line, Field: key.Value, Message: fmt.Sprintf("%q is already used by %s", value.Value, other)})
+			} else {
+				l.seen[key.Value][value.Value] = file
+			}
+		}
+	}
+
+	for _, name := range l.schema.fieldNames() {
+		if !l.schema.Fields[name].Required {
+			continue
+		}
+		if value, ok := keys[name]; !ok {
+			diags = append(diags, &Diagnostic{File: file, Line: offset, Field: name, Message: "required field is missing"})
+		} else if isEmptyNode(value) {
+			diags = append(diags, &Diagnostic{File: file, Line: value.Line + offset, Field: name, Message: "required field is empty"})
+		}
+	}
+
+	return diags
+}
+
+// unknownFieldMessage suggests the closest schema field for a likely misspelled key.
+func (l *Linter) unknownFieldMessage(key string) string {
+	best, bestDistance := "", 3
+	for _, name := range l.schema.fieldNames() {
+		if d := levenshtein(strings.ToLower(key), strings.ToLower(name)); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf("unknown field, did you mean %q?", best)
+	}
+	return "unknown field"
+}
+
+// checkField returns the problems for the value of a field, empty values are checked by Required only.
+func checkField(f *SchemaField, value *yaml.Node) []string {
+	if isEmptyNode(value) {
+		return nil
+	}
+	msgs := []string{}
+	switch f.Type {
+	case StringType:
+		if value.Kind != yaml.ScalarNode || value.Tag != "!!str" {
+			return []string{fmt.Sprintf("must be a string, got %s", nodeTypeName(value))}
+		}
+	case NumberType:
+		if value.Kind != yaml.ScalarNode || (value.Tag != "!!int" && value.Tag != "!!float") {
+			return []string{fmt.Sprintf("must be a number, got %s", nodeTypeName(value))}
+		}
+	case BoolType:
+		if value.Kind != yaml.ScalarNode || value.Tag != "!!bool" {
+			return []string{fmt.Sprintf("must be true or false, got %s", nodeTypeName(value))}
+		}
+	case DateType:
+		if value.Kind != yaml.ScalarNode {
+			return []string{fmt.Sprintf("must be a date, got %s", nodeTypeName(value))}
+		}
+		if msg := checkDate(f.Format, value.Value); msg != "" {
+			return []string{msg}
+		}
+	case ListType:
+		if value.Kind != yaml.SequenceNode {
+			return []string{fmt.Sprintf("must be a list, got %s", nodeTypeName(value))}
+		}
+	case ObjectType:
+		if value.Kind != yaml.MappingNode {
+			return []string{fmt.Sprintf("must be an object, got %s", nodeTypeName(value))}
+		}
+	}
+
+	if len(f.Enum) > 0 {
+		items := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			items = value.Content
+		}
+		for _, item := range items {
+			if item.Kind == yaml.ScalarNode && !common.Contains(f.Enum, item.Value) {
+				msgs = append(msgs, fmt.Sprintf("%q is not allowed, allowed values are: %s", item.Value, strings.Join(f.Enum, ", ")))
+			}
+		}
+	}
+	return msgs
+}
+
+func checkDate(format, value string) string {
+	if layout, ok := dateFormatLayouts[format]; ok {
+		if _, err := time.Parse(layout, value); err != nil {
+			return fmt.Sprintf("%q does not match the %s format (%s)", value, format, layout)
+		}
+		return ""
+	}
+	for _, layout := range dateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%q is not a valid date (use YYYY-MM-DD)", value)
+}
+
+func isEmptyNode(value *yaml.Node) bool {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		return value.Tag == "!!null" || (value.Tag == "!!str" && strings.TrimSpace(value.Value) == "")
+	case yaml.SequenceNode, yaml.MappingNode:
+		return len(value.Content) == 0
+	}
+	return false
+}
+
+func nodeTypeName(value *yaml.Node) string {
+	switch value.Kind {
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.MappingNode:
+		return "an object"
+	}
+	switch value.Tag {
+	case "!!str":
+		return "a string"
+	case "!!int", "!!float":
+		return "a number"
+	case "!!bool":
+		return "a boolean"
+	case "!!timestamp":
+		return "a date"
+	}
+	return value.Tag
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
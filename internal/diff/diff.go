@@ -0,0 +1,193 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+// Package diff implements a line based diff used to preview changes to text files.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OpKind identifies the kind of operation for a line in the edit script.
+type OpKind int
+
+// Edit script operations.
+const (
+	Equal OpKind = iota
+	Delete
+	Insert
+)
+
+// Line is a single line of the edit script.
+type Line struct {
+	Kind OpKind
+	Text string
+}
+
+// Lines returns the shortest edit script transforming a into b (Myers' algorithm).
+func Lines(a, b []string) []Line {
+	n, m := len(a), len(b)
+	max := n + m
+	if max == 0 {
+		return nil
+	}
+
+	offset := max
+	v := make([]int, 2*max+2)
+	trace := [][]int{}
+
+	for d := 0; d <= max; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b, offset)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrack(trace [][]int, a, b []string, offset int) []Line {
+	edits := []Line{}
+	x, y := len(a), len(b)
+
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			edits = append(edits, Line{Kind: Equal, Text: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			edits = append(edits, Line{Kind: Insert, Text: b[y-1]})
+		} else {
+			edits = append(edits, Line{Kind: Delete, Text: a[x-1]})
+		}
+		x, y = prevX, prevY
+	}
+
+	for x > 0 && y > 0 {
+		edits = append(edits, Line{Kind: Equal, Text: a[x-1]})
+		x--
+		y--
+	}
+
+	// reverse the edit script.
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// Unified returns the changes from a to b in the unified diff format,
+// with context lines around each change. It returns an empty string
+// when a and b are the same.
+func Unified(fromName, toName string, a, b []byte, context int) string {
+	edits := Lines(splitLines(a), splitLines(b))
+
+	// line numbers in a and b preceding each edit.
+	posA := make([]int, len(edits)+1)
+	posB := make([]int, len(edits)+1)
+	changes := []int{}
+	for i, e := range edits {
+		posA[i+1], posB[i+1] = posA[i], posB[i]
+		switch e.Kind {
+		case Equal:
+			posA[i+1]++
+			posB[i+1]++
+		case Delete:
+			posA[i+1]++
+			changes = append(changes, i)
+		case Insert:
+			posB[i+1]++
+			changes = append(changes, i)
+		}
+	}
+
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	for h := 0; h < len(changes); {
+		// group together changes whose distance is within the context lines.
+		last := h
+		for last+1 < len(changes) && changes[last+1]-changes[last] <= 2*context+1 {
+			last++
+		}
+		start := changes[h] - context
+		if start < 0 {
+			start = 0
+		}
+		end := changes[last] + context + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		fromStart, fromCount := posA[start]+1, posA[end]-posA[start]
+		toStart, toCount := posB[start]+1, posB[end]-posB[start]
+		if fromCount == 0 {
+			fromStart--
+		}
+		if toCount == 0 {
+			toStart--
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", fromStart, fromCount, toStart, toCount)
+
+		for _, e := range edits[start:end] {
+			switch e.Kind {
+			case Equal:
+				sb.WriteString(" ")
+			case Delete:
+				sb.WriteString("-")
+			case Insert:
+				sb.WriteString("+")
+			}
+			sb.WriteString(e.Text)
+			sb.WriteString("\n")
+		}
+		h = last + 1
+	}
+
+	return sb.String()
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return []string{}
+	}
+	return strings.Split(string(content), "\n")
+}
@@ -0,0 +1,88 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestLines(t *testing.T) {
+	is := is.New(t)
+
+	tests := []struct {
+		a    []string
+		b    []string
+		want []Line
+	}{
+		{
+			a:    []string{},
+			b:    []string{},
+			want: nil,
+		},
+		{
+			a: []string{"a", "b", "c"},
+			b: []string{"a", "b", "c"},
+			want: []Line{
+				{Kind: Equal, Text: "a"},
+				{Kind: Equal, Text: "b"},
+				{Kind: Equal, Text: "c"},
+			},
+		},
+		{
+			a: []string{"a", "b", "c"},
+			b: []string{"a", "x", "c", "d"},
+			want: []Line{
+				{Kind: Equal, Text: "a"},
+				{Kind: Delete, Text: "b"},
+				{Kind: Insert, Text: "x"},
+				{Kind: Equal, Text: "c"},
+				{Kind: Insert, Text: "d"},
+			},
+		},
+		{
+			a: []string{"a"},
+			b: []string{},
+			want: []Line{
+				{Kind: Delete, Text: "a"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		is.Equal(Lines(tc.a, tc.b), tc.want)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(Unified("a", "b", []byte("same\n"), []byte("same\n"), 3), "")
+
+	a := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12")
+	b := []byte("1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13")
+	want := `--- a/file
++++ b/file
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`
+	is.Equal(Unified("a/file", "b/file", a, b, 3), want)
+
+	want = `--- /dev/null
++++ b/file
+@@ -0,0 +1,2 @@
++new
++file
+`
+	is.Equal(Unified("/dev/null", "b/file", nil, []byte("new\nfile"), 3), want)
+}
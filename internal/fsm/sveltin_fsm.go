@@ -19,6 +19,7 @@ import (
 	"github.com/sveltinio/sveltin/helpers"
 	"github.com/sveltinio/sveltin/internal/composer"
 	"github.com/sveltinio/sveltin/internal/pathmaker"
+	"github.com/sveltinio/sveltin/internal/search"
 	"github.com/sveltinio/sveltin/internal/tpltypes"
 )
 
@@ -102,15 +103,97 @@ func (s *SveltinFSManager) NewNoPageFile(name string, data *tpltypes.ProjectSett
 	}
 }
 
-// NewMenuFile returns a pointer to a 'no-public page' File.
-func (s *SveltinFSManager) NewMenuFile(name string, resources []string, contents map[string][]string, withContentFlag bool) *composer.File {
+// NewFeedFile returns a pointer to a 'no-public page' File for the feed format (rss, atom or json).
+func (s *SveltinFSManager) NewFeedFile(format string, data *tpltypes.ProjectSettings, feed *tpltypes.FeedData) *composer.File {
+	return &composer.File{
+		Name:       helpers.FeedFilename(format),
+		TemplateID: format,
+		TemplateData: &config.TemplateData{
+			NoPage: &tpltypes.NoPageData{
+				Data: data,
+				Feed: feed,
+			},
+		},
+	}
+}
+
+// NewSitemapFile returns a pointer to a 'no-public page' File for the sitemap.
+func (s *SveltinFSManager) NewSitemapFile(name string, data *tpltypes.ProjectSettings, urls []*tpltypes.SitemapURL) *composer.File {
+	return &composer.File{
+		Name:       name + ".xml",
+		TemplateID: "sitemap",
+		TemplateData: &config.TemplateData{
+			NoPage: &tpltypes.NoPageData{
+				Data: data,
+				URLs: urls,
+			},
+		},
+	}
+}
+
+// NewSitemapIndexFile returns a pointer to a 'no-public page' File for the sitemap index.
+func (s *SveltinFSManager) NewSitemapIndexFile(name string, data *tpltypes.ProjectSettings, sitemaps []*tpltypes.SitemapFile) *composer.File {
+	return &composer.File{
+		Name:       name + ".xml",
+		TemplateID: "sitemap-index",
+		TemplateData: &config.TemplateData{
+			NoPage: &tpltypes.NoPageData{
+				Data:     data,
+				Sitemaps: sitemaps,
+			},
+		},
+	}
+}
+
+// NewRobotsFile returns a pointer to a 'no-public page' File for the robots.txt.
+func (s *SveltinFSManager) NewRobotsFile(data *tpltypes.ProjectSettings, robots *tpltypes.RobotsTxtData) *composer.File {
+	return &composer.File{
+		Name:       "robots.txt",
+		TemplateID: "robots",
+		TemplateData: &config.TemplateData{
+			NoPage: &tpltypes.NoPageData{
+				Data:   data,
+				Robots: robots,
+			},
+		},
+	}
+}
+
+// NewSearchIndexFile returns a pointer to a 'no-public page' File for the search index.
+func (s *SveltinFSManager) NewSearchIndexFile(data *tpltypes.ProjectSettings, index *search.Index) *composer.File {
+	return &composer.File{
+		Name:       helpers.SearchIndexFilename,
+		TemplateID: "search",
+		TemplateData: &config.TemplateData{
+			NoPage: &tpltypes.NoPageData{
+				Data:   data,
+				Search: index,
+			},
+		},
+	}
+}
+
+// NewSearchLibFile returns a pointer to the File for the helper used by the themes to query the search index.
+func (s *SveltinFSManager) NewSearchLibFile(data *tpltypes.ProjectSettings) *composer.File {
+	return &composer.File{
+		Name:       helpers.SearchLibFilename,
+		TemplateID: "search-lib",
+		TemplateData: &config.TemplateData{
+			NoPage: &tpltypes.NoPageData{
+				Data: data,
+			},
+		},
+	}
+}
+
+// NewMenuFile returns a pointer to the 'menu' File for the menu items.
+func (s *SveltinFSManager) NewMenuFile(name string, items []*tpltypes.MenuItem) *composer.File {
 	return &composer.File{
 		Name:       name + ".js.ts",
 		TemplateID: name,
 		TemplateData: &config.TemplateData{
 			Menu: &tpltypes.MenuData{
-				Items:       helpers.NewMenuItems(resources, contents),
-				WithContent: withContentFlag,
+				Items: items,
 			},
 		},
 	}
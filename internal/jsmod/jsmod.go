@@ -0,0 +1,675 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+// Package jsmod implements a structure-aware editor for the JavaScript/TypeScript
+// config files generated by sveltin (vite.config.ts, svelte.config.js, mdsvex.config.js).
+//
+// It does not parse the full language. It understands import declarations, the object
+// literal exported by default (e.g. export default config, export default defineConfig({...}))
+// and the object and array literals nested within it.
+package jsmod
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnsupported is returned when the module does not have the expected structure.
+	ErrUnsupported = errors.New("unsupported module structure")
+	// ErrNotFound is returned when the path does not exist within the exported object.
+	ErrNotFound = errors.New("not found")
+)
+
+func syntaxError(s string, i int, msg string) error {
+	return fmt.Errorf("%w: %s at line %d", ErrUnsupported, msg, lineNumber(s, i))
+}
+
+func unsupported(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrUnsupported, fmt.Sprintf(format, a...))
+}
+
+func notFound(path string) error {
+	return fmt.Errorf("%s: %w", path, ErrNotFound)
+}
+
+// Module is a JavaScript/TypeScript module to be edited.
+type Module struct {
+	src string
+	// root is the name of the declaration the paths are relative to. The object exported by default when empty.
+	root string
+}
+
+// Import is an import declaration.
+type Import struct {
+	// Default is the name for the default import.
+	Default string
+	// Namespace is the name for the namespace import (import * as name).
+	Namespace string
+	// Named are the named imports as written (e.g. a, b as c).
+	Named  []string
+	Source string
+	Start  int
+	End    int
+}
+
+// item is a property within an object literal or an element within an array literal.
+type item struct {
+	key        string
+	start      int
+	end        int
+	valueStart int
+	// index following the ',' after the item, end if there is none.
+	commaEnd int
+}
+
+// literal is an object or array literal.
+type literal struct {
+	open  int
+	close int
+	items []*item
+}
+
+// Parse returns the module for the source.
+func Parse(src []byte) (*Module, error) {
+	m := &Module{src: string(src)}
+	if _, err := topLevelWords(m.src); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ParseDeclaration returns the module for the source with the paths relative to the object literal
+// declared at the top level with the name (e.g. const website = {...}) rather than the one exported by default.
+func ParseDeclaration(src []byte, name string) (*Module, error) {
+	m, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	m.root = name
+	return m, nil
+}
+
+// Bytes returns the module source.
+func (m *Module) Bytes() []byte {
+	return []byte(m.src)
+}
+
+// String returns the module source.
+func (m *Module) String() string {
+	return m.src
+}
+
+//=============================================================================
+
+// Imports returns the import declarations.
+func (m *Module) Imports() []*Import {
+	words, _ := topLevelWords(m.src)
+	imports := []*Import{}
+	for _, w := range words {
+		if w.text != "import" {
+			continue
+		}
+		if imp := m.parseImport(w.start, w.end); imp != nil {
+			imports = append(imports, imp)
+		}
+	}
+	return imports
+}
+
+// FindImport returns the import declaration for the source, nil if not imported.
+func (m *Module) FindImport(source string) *Import {
+	for _, imp := range m.Imports() {
+		if imp.Source == source {
+			return imp
+		}
+	}
+	return nil
+}
+
+// AddImport adds the import statement (e.g. import a from 'a';) after the last import declaration.
+// Nothing changes when the source is already imported.
+func (m *Module) AddImport(statement string) error {
+	stmt, err := Parse([]byte(statement))
+	if err != nil {
+		return err
+	}
+	imports := stmt.Imports()
+	if len(imports) != 1 {
+		return fmt.Errorf("not valid import statement: %s", statement)
+	}
+	if m.FindImport(imports[0].Source) != nil {
+		return nil
+	}
+
+	current := m.Imports()
+	if len(current) == 0 {
+		m.src = statement + "\n" + m.src
+		return nil
+	}
+	last := current[len(current)-1]
+	at := lineEnd(m.src, last.End)
+	if at < len(m.src) {
+		at++
+	}
+	m.src = m.src[:at] + statement + "\n" + m.src[at:]
+	return nil
+}
+
+// RemoveImport removes the import declaration for the source. It returns false if not imported.
+func (m *Module) RemoveImport(source string) bool {
+	imp := m.FindImport(source)
+	if imp == nil {
+		return false
+	}
+	m.removeRange(imp.Start, imp.End)
+	return true
+}
+
+func (m *Module) parseImport(start, i int) *Import {
+	s := m.src
+	i = skipTrivia(s, i)
+	// dynamic imports and import.meta
+	if i >= len(s) || s[i] == '(' || s[i] == '.' {
+		return nil
+	}
+
+	imp := &Import{Start: start}
+	for i < len(s) && !isQuote(s[i]) {
+		switch {
+		case s[i] == '{':
+			end, err := matchBracket(s, i)
+			if err != nil {
+				return nil
+			}
+			for _, n := range strings.Split(s[i+1:end-1], ",") {
+				if n = strings.Join(strings.Fields(n), " "); n != "" {
+					imp.Named = append(imp.Named, n)
+				}
+			}
+			i = end
+		case s[i] == '*':
+			i = skipTrivia(s, i+1)
+			if as, next := readIdent(s, i); as == "as" {
+				imp.Namespace, i = readIdent(s, skipTrivia(s, next))
+			}
+		case isIdentChar(s[i]):
+			var ident string
+			ident, i = readIdent(s, i)
+			if ident != "from" && ident != "type" {
+				imp.Default = ident
+			}
+		case s[i] == ',':
+			i++
+		default:
+			return nil
+		}
+		i = skipTrivia(s, i)
+	}
+	if i >= len(s) {
+		return nil
+	}
+
+	end, err := skipString(s, i)
+	if err != nil {
+		return nil
+	}
+	imp.Source = s[i+1 : end-1]
+	if end < len(s) && s[end] == ';' {
+		end++
+	}
+	imp.End = end
+	return imp
+}
+
+//=============================================================================
+
+// HasProperty returns true if the property at the dot-separated path exists within the exported object.
+func (m *Module) HasProperty(path string) bool {
+	_, _, err := m.findItem(path)
+	return err == nil
+}
+
+// Property returns the source for the value of the property at the path.
+func (m *Module) Property(path string) (string, error) {
+	_, it, err := m.findItem(path)
+	if err != nil {
+		return "", err
+	}
+	return m.src[it.valueStart:it.end], nil
+}
+
+// AddProperty adds the property key: value to the object literal at the path ("" for the exported object).
+// The property is added as first or last one. It returns false when the property already exists.
+func (m *Module) AddProperty(objectPath, key, value string, first bool) (bool, error) {
+	lit, err := m.resolveObject(objectPath)
+	if err != nil {
+		return false, err
+	}
+	for _, it := range lit.items {
+		if it.key == key {
+			return false, nil
+		}
+	}
+	m.insertItem(lit, key+": "+value, first)
+	return true, nil
+}
+
+// RemoveProperty removes the property at the path. It returns false when the property does not exist.
+func (m *Module) RemoveProperty(path string) (bool, error) {
+	lit, it, err := m.findItem(path)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	m.removeItem(lit, it)
+	return true, nil
+}
+
+// ArrayElements returns the source for the elements of the array literal at the path.
+func (m *Module) ArrayElements(path string) ([]string, error) {
+	lit, err := m.resolveArray(path)
+	if err != nil {
+		return nil, err
+	}
+	elements := []string{}
+	for _, it := range lit.items {
+		elements = append(elements, m.src[it.start:it.end])
+	}
+	return elements, nil
+}
+
+// AddArrayElement adds the element to the array literal at the path, as first or last one.
+func (m *Module) AddArrayElement(path, element string, first bool) error {
+	lit, err := m.resolveArray(path)
+	if err != nil {
+		return err
+	}
+	m.insertItem(lit, element, first)
+	return nil
+}
+
+// RemoveArrayElements removes the elements matching from the array literal at the path.
+// It returns the number of removed elements.
+func (m *Module) RemoveArrayElements(path string, match func(string) bool) (int, error) {
+	removed := 0
+	for {
+		lit, err := m.resolveArray(path)
+		if err != nil {
+			return removed, err
+		}
+		var found *item
+		for _, it := range lit.items {
+			if match(m.src[it.start:it.end]) {
+				found = it
+				break
+			}
+		}
+		if found == nil {
+			return removed, nil
+		}
+		m.removeItem(lit, found)
+		removed++
+	}
+}
+
+// ReplaceArrayElements replaces the elements matching in the array literal at the path.
+// It returns the number of replaced elements.
+func (m *Module) ReplaceArrayElements(path string, match func(string) bool, element string) (int, error) {
+	lit, err := m.resolveArray(path)
+	if err != nil {
+		return 0, err
+	}
+	replaced := 0
+	// walk backward so the offsets of the previous items stay valid.
+	for i := len(lit.items) - 1; i >= 0; i-- {
+		it := lit.items[i]
+		if current := m.src[it.start:it.end]; current != element && match(current) {
+			m.src = m.src[:it.start] + element + m.src[it.end:]
+			replaced++
+		}
+	}
+	return replaced, nil
+}
+
+//=============================================================================
+
+// exportedObject returns the offset of the '{' for the object literal exported by default.
+func (m *Module) exportedObject() (int, error) {
+	words, err := topLevelWords(m.src)
+	if err != nil {
+		return 0, err
+	}
+	if m.root != "" {
+		return m.resolveDeclaration(words, m.root, 0)
+	}
+	for i, w := range words {
+		if w.text == "export" && i+1 < len(words) && words[i+1].text == "default" {
+			return m.resolveExpression(words, words[i+1].end, 0)
+		}
+	}
+	return 0, unsupported("no export default found")
+}
+
+// resolveExpression returns the offset of the '{' for the object literal the expression at i evaluates to.
+// Supported expressions are object literals, calls with an object literal as first argument
+// (e.g. defineConfig({...})) and identifiers declared at the top level with one of them.
+func (m *Module) resolveExpression(words []*word, i, depth int) (int, error) {
+	s := m.src
+	i = skipTrivia(s, i)
+	if i >= len(s) {
+		return 0, unsupported("missing expression at line %d", lineNumber(s, i))
+	}
+	if s[i] == '{' {
+		return i, nil
+	}
+	if !isIdentChar(s[i]) {
+		return 0, unsupported("not an object literal at line %d", lineNumber(s, i))
+	}
+
+	ident, next := readIdent(s, i)
+	next = skipTrivia(s, next)
+	if next < len(s) && s[next] == '(' {
+		arg := skipTrivia(s, next+1)
+		if arg < len(s) && s[arg] == '{' {
+			return arg, nil
+		}
+		return 0, unsupported("%s() is not called with an object literal at line %d", ident, lineNumber(s, i))
+	}
+
+	if depth > 2 {
+		return 0, unsupported("%s is not an object literal at line %d", ident, lineNumber(s, i))
+	}
+	return m.resolveDeclaration(words, ident, depth)
+}
+
+// resolveDeclaration returns the offset of the '{' for the object literal the top level declaration
+// for ident evaluates to.
+func (m *Module) resolveDeclaration(words []*word, ident string, depth int) (int, error) {
+	s := m.src
+	for j, w := range words {
+		if (w.text == "const" || w.text == "let" || w.text == "var") && j+1 < len(words) && words[j+1].text == ident {
+			eq := skipTrivia(s, words[j+1].end)
+			// skip the TS type annotation, if any.
+			if eq < len(s) && s[eq] == ':' {
+				end, err := skipTypeAnnotation(s, eq+1)
+				if err != nil {
+					return 0, err
+				}
+				eq = end
+			}
+			if eq < len(s) && s[eq] == '=' {
+				return m.resolveExpression(words, eq+1, depth+1)
+			}
+		}
+	}
+	return 0, unsupported("declaration for %s not found", ident)
+}
+
+// parseLiteral returns the object or array literal starting at open.
+func (m *Module) parseLiteral(open int) (*literal, error) {
+	s := m.src
+	end, err := matchBracket(s, open)
+	if err != nil {
+		return nil, err
+	}
+	lit := &literal{open: open, close: end - 1}
+	isObject := s[open] == '{'
+
+	for i := open + 1; ; {
+		i = skipTrivia(s, i)
+		if i >= lit.close {
+			break
+		}
+		it := &item{start: i, valueStart: i}
+		if isObject {
+			switch {
+			case strings.HasPrefix(s[i:], "..."):
+				it.key = "..."
+			case isQuote(s[i]):
+				keyEnd, err := skipString(s, i)
+				if err != nil {
+					return nil, err
+				}
+				it.key = s[i+1 : keyEnd-1]
+				it.valueStart = keyEnd
+			case isIdentChar(s[i]):
+				it.key, it.valueStart = readIdent(s, i)
+			}
+			if colon := skipTrivia(s, it.valueStart); colon < lit.close && s[colon] == ':' {
+				it.valueStart = skipTrivia(s, colon+1)
+			} else {
+				it.valueStart = i
+			}
+		}
+
+		stop, err := skipItem(s, it.valueStart, lit.close)
+		if err != nil {
+			return nil, err
+		}
+		it.end = trimRightSpace(s, it.start, stop)
+		it.commaEnd = it.end
+		if stop < lit.close {
+			it.commaEnd = stop + 1
+		}
+		lit.items = append(lit.items, it)
+		i = stop + 1
+	}
+	return lit, nil
+}
+
+// resolveObject returns the object literal at the dot-separated path within the exported object.
+func (m *Module) resolveObject(path string) (*literal, error) {
+	open, err := m.exportedObject()
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return m.parseLiteral(open)
+	}
+	_, it, err := m.findItem(path)
+	if err != nil {
+		return nil, err
+	}
+	open, err = m.resolveValue(it.valueStart, path)
+	if err != nil {
+		return nil, err
+	}
+	return m.parseLiteral(open)
+}
+
+// resolveArray returns the array literal at the dot-separated path within the exported object.
+func (m *Module) resolveArray(path string) (*literal, error) {
+	_, it, err := m.findItem(path)
+	if err != nil {
+		return nil, err
+	}
+	if m.src[it.valueStart] != '[' {
+		return nil, unsupported("%s is not an array literal at line %d", path, lineNumber(m.src, it.valueStart))
+	}
+	return m.parseLiteral(it.valueStart)
+}
+
+// resolveValue returns the offset of the '{' for the object literal the property value evaluates to.
+func (m *Module) resolveValue(i int, path string) (int, error) {
+	words, err := topLevelWords(m.src)
+	if err != nil {
+		return 0, err
+	}
+	open, err := m.resolveExpression(words, i, 0)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", path, err)
+	}
+	return open, nil
+}
+
+// findItem returns the property at the dot-separated path and the object literal containing it.
+func (m *Module) findItem(path string) (*literal, *item, error) {
+	open, err := m.exportedObject()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		lit, err := m.parseLiteral(open)
+		if err != nil {
+			return nil, nil, err
+		}
+		var found *item
+		for _, it := range lit.items {
+			if it.key == key {
+				found = it
+			}
+		}
+		if found == nil {
+			return nil, nil, notFound(strings.Join(keys[:i+1], "."))
+		}
+		if i == len(keys)-1 {
+			return lit, found, nil
+		}
+		if open, err = m.resolveValue(found.valueStart, strings.Join(keys[:i+1], ".")); err != nil {
+			return nil, nil, err
+		}
+	}
+	return nil, nil, notFound(path)
+}
+
+//=============================================================================
+
+// insertItem adds the text as first or last item of the literal, following the existing formatting.
+func (m *Module) insertItem(lit *literal, text string, first bool) {
+	s := m.src
+	if len(lit.items) == 0 {
+		indent := indentAt(s, lit.open)
+		if !isBlank(s[lit.open+1 : lit.close]) {
+			m.src = s[:lit.close] + text + s[lit.close:]
+			return
+		}
+		m.src = s[:lit.open+1] + "\n" + indent + indentUnit(s) + text + "\n" + indent + s[lit.close:]
+		return
+	}
+
+	multiline := strings.Contains(s[lit.open:lit.items[0].start], "\n")
+	if first {
+		it := lit.items[0]
+		if multiline {
+			at := lineStart(s, it.start)
+			m.src = s[:at] + indentAt(s, it.start) + text + ",\n" + s[at:]
+		} else {
+			m.src = s[:it.start] + text + ", " + s[it.start:]
+		}
+		return
+	}
+
+	last := lit.items[len(lit.items)-1]
+	switch {
+	case multiline && last.commaEnd > last.end:
+		m.src = s[:last.commaEnd] + "\n" + indentAt(s, last.start) + text + "," + s[last.commaEnd:]
+	case multiline:
+		m.src = s[:last.end] + ",\n" + indentAt(s, last.start) + text + s[last.end:]
+	default:
+		m.src = s[:last.end] + ", " + text + s[last.end:]
+	}
+}
+
+// removeItem removes the item from the literal along with its ','.
+func (m *Module) removeItem(lit *literal, it *item) {
+	if it.commaEnd > it.end {
+		end := it.commaEnd
+		for end < len(m.src) && (m.src[end] == ' ' || m.src[end] == '\t') {
+			end++
+		}
+		m.removeRange(it.start, end)
+		return
+	}
+	// the last item with no trailing ','.
+	for i, current := range lit.items {
+		if current == it && i > 0 && !strings.Contains(m.src[lit.items[i-1].end:it.start], "\n") {
+			m.src = m.src[:lit.items[i-1].end] + m.src[it.end:]
+			return
+		}
+	}
+	m.removeRange(it.start, it.end)
+}
+
+// removeRange removes the text between start and end. The whole line is removed
+// when nothing else is on it.
+func (m *Module) removeRange(start, end int) {
+	s := m.src
+	ls, le := lineStart(s, start), lineEnd(s, end)
+	if isBlank(s[ls:start]) && isBlank(s[end:le]) {
+		if le < len(s) {
+			le++
+		}
+		m.src = s[:ls] + s[le:]
+		return
+	}
+	m.src = s[:start] + s[end:]
+}
+
+// indentUnit returns the indentation unit used by the source, defaults to tab.
+func indentUnit(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			return "\t"
+		}
+		if strings.HasPrefix(line, "  ") {
+			n := len(line) - len(strings.TrimLeft(line, " "))
+			if n >= 4 {
+				return "    "
+			}
+			return "  "
+		}
+	}
+	return "\t"
+}
+
+// Unquote returns the value of the string literal (e.g. 'a', "a" or `a`). It returns false when
+// value is not a string literal or it is a template literal with substitutions.
+func Unquote(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 {
+		return "", false
+	}
+	quote := value[0]
+	if (quote != '\'' && quote != '"' && quote != '`') || value[len(value)-1] != quote {
+		return "", false
+	}
+	if quote == '`' && strings.Contains(value, "${") {
+		return "", false
+	}
+
+	var sb strings.Builder
+	body := value[1 : len(value)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c == quote {
+			// the literal ends before the last quote (e.g. 'a' + 'b').
+			return "", false
+		}
+		if c != '\\' || i+1 == len(body) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		switch body[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		default:
+			sb.WriteByte(body[i])
+		}
+	}
+	return sb.String(), true
+}
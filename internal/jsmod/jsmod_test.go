@@ -0,0 +1,263 @@
+package jsmod
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+const viteConfig = `import { resolve, join, dirname } from 'path';
+import { defineConfig } from 'vite';
+import { sveltekit } from '@sveltejs/kit/vite';
+
+const config = defineConfig({
+	server: {
+		// Allow serving files from one level up to the project root
+		fs: { allow: ['..'] },
+	},
+	resolve: {
+		alias: {
+			$config: resolve(join(__dirname, './config')),
+			$content: resolve(join(__dirname, './content'))
+		},
+	},
+	plugins: [sveltekit()],
+});
+
+export default config;
+`
+
+const svelteConfig = `/** @type {import('@sveltejs/kit').Config} */
+export default {
+	kit: {
+		trailingSlash: 'always',
+		prerender: { crawl: true, enabled: true, entries: ['*'] },
+	},
+};
+`
+
+func TestImports(t *testing.T) {
+	is := is.New(t)
+
+	m, err := Parse([]byte(viteConfig))
+	is.NoErr(err)
+
+	imports := m.Imports()
+	is.Equal(len(imports), 3)
+	is.Equal(imports[0].Named, []string{"resolve", "join", "dirname"})
+	is.Equal(imports[0].Source, "path")
+	is.Equal(m.FindImport("vite").Named, []string{"defineConfig"})
+
+	is.NoErr(m.AddImport("import path from 'path';"))
+	is.Equal(len(m.Imports()), 3)
+
+	is.NoErr(m.AddImport("import { mdsvex } from 'mdsvex';"))
+	is.True(strings.Contains(m.String(), "import { sveltekit } from '@sveltejs/kit/vite';\nimport { mdsvex } from 'mdsvex';\n\nconst config"))
+
+	is.True(m.RemoveImport("vite"))
+	is.True(!strings.Contains(m.String(), "defineConfig }"))
+	is.True(!m.RemoveImport("vite"))
+}
+
+func TestProperties(t *testing.T) {
+	is := is.New(t)
+
+	m, err := Parse([]byte(viteConfig))
+	is.NoErr(err)
+
+	is.True(m.HasProperty("resolve.alias.$config"))
+	is.True(!m.HasProperty("resolve.alias.$sveltin"))
+
+	value, err := m.Property("resolve.alias.$content")
+	is.NoErr(err)
+	is.Equal(value, "resolve(join(__dirname, './content'))")
+
+	added, err := m.AddProperty("resolve.alias", "$sveltin", "resolve(join(__dirname, './src/sveltin'))", true)
+	is.NoErr(err)
+	is.True(added)
+	is.True(strings.Contains(m.String(), "\t\talias: {\n\t\t\t$sveltin: resolve(join(__dirname, './src/sveltin')),\n\t\t\t$config:"))
+
+	added, err = m.AddProperty("resolve.alias", "$sveltin", "x", true)
+	is.NoErr(err)
+	is.True(!added)
+
+	// last property with no trailing comma.
+	_, err = m.AddProperty("resolve.alias", "$themes", "resolve(join(__dirname, './themes'))", false)
+	is.NoErr(err)
+	is.True(strings.Contains(m.String(), "'./content')),\n\t\t\t$themes: resolve(join(__dirname, './themes'))\n\t\t},"))
+
+	// single line object.
+	_, err = m.AddProperty("server.fs", "strict", "false", false)
+	is.NoErr(err)
+	is.True(strings.Contains(m.String(), "fs: { allow: ['..'], strict: false },"))
+
+	_, err = m.AddProperty("missing", "a", "b", false)
+	is.True(errors.Is(err, ErrNotFound))
+}
+
+func TestRemoveProperty(t *testing.T) {
+	is := is.New(t)
+
+	m, err := Parse([]byte(svelteConfig))
+	is.NoErr(err)
+
+	removed, err := m.RemoveProperty("kit.trailingSlash")
+	is.NoErr(err)
+	is.True(removed)
+
+	removed, err = m.RemoveProperty("kit.prerender.enabled")
+	is.NoErr(err)
+	is.True(removed)
+
+	removed, err = m.RemoveProperty("kit.prerender.enabled")
+	is.NoErr(err)
+	is.True(!removed)
+
+	want := `/** @type {import('@sveltejs/kit').Config} */
+export default {
+	kit: {
+		prerender: { crawl: true, entries: ['*'] },
+	},
+};
+`
+	is.Equal(m.String(), want)
+}
+
+func TestArrays(t *testing.T) {
+	is := is.New(t)
+
+	src := `const mdsvexConfig = defineConfig({
+	remarkPlugins: [headings, remarkSlug, emoji, [remarkExternalLinks, { target: '_blank' }]],
+	rehypePlugins: [
+		rehypeSlug,
+		[rehypeAutoLinkHeadings, { behavior: 'wrap' }]
+	],
+});
+
+export default mdsvexConfig;
+`
+	m, err := Parse([]byte(src))
+	is.NoErr(err)
+
+	elements, err := m.ArrayElements("remarkPlugins")
+	is.NoErr(err)
+	is.Equal(len(elements), 4)
+	is.Equal(elements[3], "[remarkExternalLinks, { target: '_blank' }]")
+
+	n, err := m.RemoveArrayElements("remarkPlugins", func(e string) bool {
+		return e == "remarkSlug" || strings.HasPrefix(e, "[remarkExternalLinks")
+	})
+	is.NoErr(err)
+	is.Equal(n, 2)
+
+	is.NoErr(m.AddArrayElement("rehypePlugins", "[rehypeExternalLinks, { target: '_blank' }]", false))
+
+	want := `const mdsvexConfig = defineConfig({
+	remarkPlugins: [headings, emoji],
+	rehypePlugins: [
+		rehypeSlug,
+		[rehypeAutoLinkHeadings, { behavior: 'wrap' }],
+		[rehypeExternalLinks, { target: '_blank' }]
+	],
+});
+
+export default mdsvexConfig;
+`
+	is.Equal(m.String(), want)
+
+	_, err = m.ArrayElements("extensions")
+	is.True(errors.Is(err, ErrNotFound))
+}
+
+func TestUnsupported(t *testing.T) {
+	is := is.New(t)
+
+	_, err := Parse([]byte("const a = { b: [1, 2 };"))
+	is.True(errors.Is(err, ErrUnsupported))
+
+	m, err := Parse([]byte("module.exports = { a: 1 };"))
+	is.NoErr(err)
+	_, err = m.AddProperty("", "b", "2", false)
+	is.True(errors.Is(err, ErrUnsupported))
+
+	m, err = Parse([]byte("export default getConfig(options);"))
+	is.NoErr(err)
+	is.True(!m.HasProperty("a"))
+	_, err = m.RemoveProperty("a")
+	is.True(errors.Is(err, ErrUnsupported))
+}
+
+func TestParseDeclaration(t *testing.T) {
+	is := is.New(t)
+
+	src := `import { name, baseurl } from '../sveltin.json';
+import type { Sveltin } from '$sveltin';
+
+const website: Sveltin.WebSite = {
+	name: name,
+	baseURL: baseurl,
+	language: 'en-GB',
+	title: "It's \"mine\"",
+	copyright: '2023'.concat(" - ", name),
+	creator: {
+		name: 'YOUR_NAME_HERE',
+	}
+};
+
+export { website };
+`
+	m, err := ParseDeclaration([]byte(src), "website")
+	is.NoErr(err)
+
+	value, err := m.Property("language")
+	is.NoErr(err)
+	is.Equal(value, "'en-GB'")
+	language, ok := Unquote(value)
+	is.True(ok)
+	is.Equal(language, "en-GB")
+
+	value, err = m.Property("title")
+	is.NoErr(err)
+	title, ok := Unquote(value)
+	is.True(ok)
+	is.Equal(title, `It's "mine"`)
+
+	value, err = m.Property("creator.name")
+	is.NoErr(err)
+	is.Equal(value, "'YOUR_NAME_HERE'")
+
+	for _, v := range []string{"name", "'2023'.concat(\" - \", name)", "`${name}`", "'a' + 'b'"} {
+		_, ok := Unquote(v)
+		is.True(!ok) // not a string literal
+	}
+
+	_, err = m.Property("missing")
+	is.True(errors.Is(err, ErrNotFound))
+}
+
+func TestParseDeclarationTypeAnnotation(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "type reference", src: "const config: Config = { a: 1 };"},
+		{name: "function type", src: "const config: (a: A) => B = { a: 1 };"},
+		{name: "type arguments", src: "const config: Record<string, (v: string) => boolean> = { a: 1 };"},
+		{name: "object type", src: "const config: { a: number; check: (v: number) => boolean } = { a: 1 };"},
+		{name: "string literal type", src: "const config: Config<'a=b'> = { a: 1 };"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			m, err := ParseDeclaration([]byte(tc.src), "config")
+			is.NoErr(err)
+			value, err := m.Property("a")
+			is.NoErr(err)
+			is.Equal(value, "1")
+		})
+	}
+}
@@ -0,0 +1,274 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package jsmod
+
+import (
+	"strings"
+)
+
+// word is an identifier or keyword found at the top level of the module.
+type word struct {
+	text  string
+	start int
+	end   int
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isQuote(c byte) bool {
+	return c == '\'' || c == '"' || c == '`'
+}
+
+func isComment(s string, i int) bool {
+	return strings.HasPrefix(s[i:], "//") || strings.HasPrefix(s[i:], "/*")
+}
+
+// skipTrivia returns the index of the first character after whitespaces and comments.
+func skipTrivia(s string, i int) int {
+	for i < len(s) {
+		switch {
+		case isSpace(s[i]):
+			i++
+		case strings.HasPrefix(s[i:], "//"):
+			end := strings.IndexByte(s[i:], '\n')
+			if end == -1 {
+				return len(s)
+			}
+			i += end + 1
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end == -1 {
+				return len(s)
+			}
+			i += end + 4
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// skipString returns the index after the string literal starting at i.
+func skipString(s string, i int) (int, error) {
+	quote := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1, nil
+		case '$':
+			if quote == '`' && j+1 < len(s) && s[j+1] == '{' {
+				end, err := matchBracket(s, j+1)
+				if err != nil {
+					return 0, err
+				}
+				j = end - 1
+			}
+		case '\n':
+			if quote != '`' {
+				return 0, syntaxError(s, i, "unterminated string")
+			}
+		}
+	}
+	return 0, syntaxError(s, i, "unterminated string")
+}
+
+// matchBracket returns the index after the bracket closing the one at i.
+func matchBracket(s string, i int) (int, error) {
+	stack := []byte{}
+	for j := i; j < len(s); {
+		c := s[j]
+		switch {
+		case isQuote(c):
+			end, err := skipString(s, j)
+			if err != nil {
+				return 0, err
+			}
+			j = end
+			continue
+		case c == '/' && isComment(s, j):
+			j = skipTrivia(s, j)
+			continue
+		case c == '(':
+			stack = append(stack, ')')
+		case c == '[':
+			stack = append(stack, ']')
+		case c == '{':
+			stack = append(stack, '}')
+		case c == ')' || c == ']' || c == '}':
+			if len(stack) == 0 || stack[len(stack)-1] != c {
+				return 0, syntaxError(s, j, "unexpected "+string(c))
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return j + 1, nil
+			}
+		}
+		j++
+	}
+	return 0, syntaxError(s, i, "unclosed "+string(s[i]))
+}
+
+// skipItem returns the index of the ',' ending the list item starting at i,
+// or limit when the item is the last one.
+func skipItem(s string, i, limit int) (int, error) {
+	for i < limit {
+		c := s[i]
+		switch {
+		case isQuote(c):
+			end, err := skipString(s, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+			continue
+		case c == '/' && isComment(s, i):
+			i = skipTrivia(s, i)
+			continue
+		case c == '(' || c == '[' || c == '{':
+			end, err := matchBracket(s, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+			continue
+		case c == ',':
+			return i, nil
+		}
+		i++
+	}
+	return limit, nil
+}
+
+// skipTypeAnnotation returns the index of the '=' ending the TS type annotation starting at i,
+// or the end of s when there is none. Brackets, type arguments and the '=>' of function types are skipped.
+func skipTypeAnnotation(s string, i int) (int, error) {
+	angles := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case isQuote(c):
+			end, err := skipString(s, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+			continue
+		case c == '/' && isComment(s, i):
+			i = skipTrivia(s, i)
+			continue
+		case c == '(' || c == '[' || c == '{':
+			end, err := matchBracket(s, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+			continue
+		case strings.HasPrefix(s[i:], "=>"):
+			i += 2
+			continue
+		case c == '<':
+			angles++
+		case c == '>' && angles > 0:
+			angles--
+		case c == '=' && angles == 0:
+			return i, nil
+		}
+		i++
+	}
+	return len(s), nil
+}
+
+// topLevelWords returns the identifiers and keywords outside of any bracket.
+func topLevelWords(s string) ([]*word, error) {
+	words := []*word{}
+	for i := 0; i < len(s); {
+		i = skipTrivia(s, i)
+		if i >= len(s) {
+			break
+		}
+		c := s[i]
+		switch {
+		case isQuote(c):
+			end, err := skipString(s, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		case c == '(' || c == '[' || c == '{':
+			end, err := matchBracket(s, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		case c == ')' || c == ']' || c == '}':
+			return nil, syntaxError(s, i, "unexpected "+string(c))
+		case isIdentChar(c):
+			start := i
+			for i < len(s) && isIdentChar(s[i]) {
+				i++
+			}
+			words = append(words, &word{text: s[start:i], start: start, end: i})
+		default:
+			i++
+		}
+	}
+	return words, nil
+}
+
+// readIdent returns the identifier starting at i and the index following it.
+func readIdent(s string, i int) (string, int) {
+	start := i
+	for i < len(s) && isIdentChar(s[i]) {
+		i++
+	}
+	return s[start:i], i
+}
+
+func lineStart(s string, i int) int {
+	return strings.LastIndexByte(s[:i], '\n') + 1
+}
+
+func lineEnd(s string, i int) int {
+	if end := strings.IndexByte(s[i:], '\n'); end != -1 {
+		return i + end
+	}
+	return len(s)
+}
+
+func lineNumber(s string, i int) int {
+	return strings.Count(s[:i], "\n") + 1
+}
+
+func indentAt(s string, i int) string {
+	start := lineStart(s, i)
+	end := start
+	for end < len(s) && (s[end] == ' ' || s[end] == '\t') {
+		end++
+	}
+	return s[start:end]
+}
+
+func isBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+func trimRightSpace(s string, start, end int) int {
+	for end > start && isSpace(s[end-1]) {
+		end--
+	}
+	return end
+}
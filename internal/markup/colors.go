@@ -18,6 +18,8 @@ var (
 	green = lipgloss.AdaptiveColor{Light: "#16a34a", Dark: "#22c55e"}
 	// Light: gray-600, Dark: gray-500
 	gray = lipgloss.AdaptiveColor{Light: "#4b5563", Dark: "#64748b"}
+	// Light: red-600, Dark: red-500
+	red = lipgloss.AdaptiveColor{Light: "#dc2626", Dark: "#ef4444"}
 	// Light: yellow-600, Dark: yellow-500
 	yellow = lipgloss.AdaptiveColor{Light: "#ca8a04", Dark: "#eab308"}
 	// Light: amber-600, Dark: amber-500
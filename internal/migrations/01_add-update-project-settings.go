@@ -10,17 +10,18 @@ package migrations
 import (
 	"bytes"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/spf13/afero"
 	"github.com/sveltinio/sveltin/common"
 	"github.com/sveltinio/sveltin/config"
+	"github.com/sveltinio/sveltin/helpers"
 	"github.com/sveltinio/sveltin/helpers/factory"
 	"github.com/sveltinio/sveltin/internal/tpltypes"
 	"github.com/sveltinio/sveltin/resources"
 	"github.com/sveltinio/sveltin/utils"
+	"github.com/tidwall/sjson"
 )
 
 // AddUpdateProjectSettings is the struct representing the migration add the sveltin.json file.
@@ -128,17 +129,11 @@ func addProjectSettingsFile(m *AddUpdateProjectSettings) error {
 	}
 	sveltinJSONConfigFile := m.getServices().fsManager.NewJSONConfigFile(sveltinConfigTplData)
 
-	cwd, _ := os.Getwd()
-	projectFolderName := filepath.Base(cwd)
-	projectFolder := m.getServices().fsManager.GetFolder(projectFolderName)
-	projectFolder.Add(sveltinJSONConfigFile)
-
 	sfs := factory.NewProjectArtifact(&resources.SveltinTemplatesFS, m.getServices().fs)
-	err = projectFolder.Create(sfs)
-	if err != nil {
-		return err
-	}
-	return nil
+	preparedContent := helpers.PrepareContent(sfs.GetBuilder(), sfs.GetResources(), sveltinJSONConfigFile.GetTemplateID(), sveltinJSONConfigFile.GetTemplateData())
+	fileContent := helpers.MakeFileContent(sfs.GetEFS(), preparedContent)
+
+	return writeFile(m, m.Data.TargetPath, fileContent)
 }
 
 func makeThemeData(m *AddUpdateProjectSettings) (*tpltypes.ThemeData, error) {
@@ -148,7 +143,7 @@ func makeThemeData(m *AddUpdateProjectSettings) (*tpltypes.ThemeData, error) {
 	)
 
 	themeData := &tpltypes.ThemeData{}
-	files, err := afero.ReadDir(m.getServices().fs, m.getServices().pathMaker.GetThemesFolder())
+	files, err := afero.ReadDir(m.getServices().fs, filepath.Join(m.getServices().pathMaker.GetRootFolder(), m.getServices().pathMaker.GetThemesFolder()))
 	if err != nil {
 		return nil, err
 	}
@@ -172,10 +167,15 @@ func updateFileContent(m *AddUpdateProjectSettings) error {
 		return err
 	}
 
+	if m.Data.ProjectCliVersion == "" {
+		// the file has no version to bump.
+		newContent, err := sjson.SetBytes(content, "sveltin.version", m.Data.CliVersion)
+		if err != nil {
+			return err
+		}
+		return writeFile(m, m.Data.TargetPath, newContent)
+	}
 	newContent := bytes.Replace(content, []byte(m.Data.ProjectCliVersion), []byte(m.Data.CliVersion), -1)
 
-	if err = afero.WriteFile(m.getServices().fs, m.Data.TargetPath, newContent, 0666); err != nil {
-		return err
-	}
-	return nil
+	return writeFile(m, m.Data.TargetPath, newContent)
 }
@@ -12,7 +12,6 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/spf13/afero"
 	"github.com/sveltinio/sveltin/common"
 	"github.com/sveltinio/sveltin/utils"
 )
@@ -78,9 +77,11 @@ func (m *RefactorWebSiteTSTypes) up() error {
 			localFilePath :=
 				strings.Replace(m.Data.TargetPath, m.getServices().pathMaker.GetRootFolder(), "", 1)
 			m.getServices().logger.Info(fmt.Sprintf("Migrating %s", localFilePath))
-			if _, err := m.runMigration(fileContent, ""); err != nil {
+			migratedContent, err := m.runMigration(fileContent, "")
+			if err != nil {
 				return err
 			}
+			return writeFile(m, m.Data.TargetPath, migratedContent)
 		}
 	}
 
@@ -102,22 +103,14 @@ func (m *RefactorWebSiteTSTypes) runMigration(content []byte, file string) ([]by
 			replaceWebmasterPropRule(line),
 			replaceContactEmailPropRule(line, prevLine),
 		}
-		if res, ok := applyMigrationRules(rules); ok {
+		if res, ok := applyMigrationRules(m, rules); ok {
 			lines[i] = res
 		} else {
 			lines[i] = line
 		}
 	}
 	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(m.Data.TargetPath)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = afero.WriteFile(m.getServices().fs, m.Data.TargetPath, []byte(output), 0644); err != nil {
-		return nil, err
-	}
-	return nil, nil
+	return []byte(output), nil
 }
 
 func (m *RefactorWebSiteTSTypes) down() error {
@@ -11,7 +11,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/spf13/afero"
 	"github.com/sveltinio/sveltin/common"
 )
 
@@ -70,9 +69,11 @@ func (m *RefactorMenuTSTypes) up() error {
 			localFilePath :=
 				strings.Replace(m.Data.TargetPath, m.getServices().pathMaker.GetRootFolder(), "", 1)
 			m.getServices().logger.Info(fmt.Sprintf("Migrating %s", localFilePath))
-			if _, err := m.runMigration(fileContent, ""); err != nil {
+			migratedContent, err := m.runMigration(fileContent, "")
+			if err != nil {
 				return err
 			}
+			return writeFile(m, m.Data.TargetPath, migratedContent)
 		}
 	}
 
@@ -86,22 +87,14 @@ func (m *RefactorMenuTSTypes) runMigration(content []byte, file string) ([]byte,
 			newMenuTSImportRule(line),
 			newMenuTSUsageRule(line),
 		}
-		if res, ok := applyMigrationRules(rules); ok {
+		if res, ok := applyMigrationRules(m, rules); ok {
 			lines[i] = res
 		} else {
 			lines[i] = line
 		}
 	}
 	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(m.Data.TargetPath)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = afero.WriteFile(m.getServices().fs, m.Data.TargetPath, []byte(output), 0644); err != nil {
-		return nil, err
-	}
-	return nil, nil
+	return []byte(output), nil
 }
 
 func (m *RefactorMenuTSTypes) down() error {
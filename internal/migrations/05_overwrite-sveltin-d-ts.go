@@ -9,7 +9,6 @@ package migrations
 
 import (
 	"fmt"
-	"path"
 	"strings"
 
 	"github.com/sveltinio/sveltin/common"
@@ -68,8 +67,11 @@ func (m *OverwriteSveltinDTS) up() error {
 			localFilePath :=
 				strings.Replace(m.Data.TargetPath, m.getServices().pathMaker.GetRootFolder(), "", 1)
 			m.getServices().logger.Info(fmt.Sprintf("Migrating %s", localFilePath))
-			saveTo := path.Join(m.Services.pathMaker.GetSrcFolder())
-			return m.Services.fsManager.CopyFileFromEmbed(&resources.SveltinStaticFS, m.Services.fs, resources.SveltinFilesFS, "sveltin_d_ts", saveTo)
+			migratedContent, err := m.runMigration(fileContent, "")
+			if err != nil {
+				return err
+			}
+			return writeFile(m, m.Data.TargetPath, migratedContent)
 		}
 	}
 
@@ -91,7 +93,7 @@ func (m *OverwriteSveltinDTS) allowUp() error {
 }
 
 func (m *OverwriteSveltinDTS) runMigration(content []byte, file string) ([]byte, error) {
-	return nil, nil
+	return resources.SveltinStaticFS.ReadFile(resources.SveltinFilesFS["sveltin_d_ts"])
 }
 
 //=============================================================================
@@ -69,7 +69,7 @@ func (m *RefactorResourcesLibsTypes) up() error {
 
 		err := afero.Walk(m.getServices().fs, m.Data.TargetPath, walkFunc)
 		if err != nil {
-			m.getServices().logger.Fatalf("Something went wrong visiting the folder %s. Are you sure it exists?", m.Data.TargetPath)
+			return fmt.Errorf("something went wrong visiting the folder %s: %w", m.Data.TargetPath, err)
 		}
 
 		migrationTriggers := []string{
@@ -90,24 +90,24 @@ func (m *RefactorResourcesLibsTypes) up() error {
 				return err
 			}
 
+			updatedContent := fileContent
 			localFilePath :=
 				strings.Replace(file, m.getServices().pathMaker.GetRootFolder(), "", 1)
 			if patternsMatched(fileContent, migrationTriggers, findStringMatcher) {
 				m.getServices().logger.Info(fmt.Sprintf("Migrating %s", localFilePath))
-				if _, err := m.runMigration(fileContent, file); err != nil {
+				if updatedContent, err = m.runMigration(fileContent, file); err != nil {
 					return err
 				}
 			}
 
-			contentsToAppend := []string{}
-
 			if isStringsLib(localFilePath) && mustMigrate(fileContent, "canonicalPageUrl") {
 				const canonicalPageUrlFunction = "\nexport const canonicalPageUrl = (name: string, baseURL: string): string => baseURL.concat(name);"
-				contentsToAppend = append(contentsToAppend, canonicalPageUrlFunction)
+				updatedContent = append(updatedContent, []byte(canonicalPageUrlFunction)...)
 			}
 
-			appendToFile(m.getServices().fs, file, contentsToAppend, m.getServices().logger)
-
+			if err := writeFile(m, file, updatedContent); err != nil {
+				return err
+			}
 		}
 
 	}
@@ -133,7 +133,7 @@ func (m *RefactorResourcesLibsTypes) runMigration(content []byte, file string) (
 				newToSlug(line),
 			}
 
-			if res, ok := applyMigrationRules(rules); ok {
+			if res, ok := applyMigrationRules(m, rules); ok {
 				lines[i] = res
 			} else {
 				lines[i] = line
@@ -142,16 +142,7 @@ func (m *RefactorResourcesLibsTypes) runMigration(content []byte, file string) (
 		}
 	}
 	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(file)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = afero.WriteFile(m.getServices().fs, file, []byte(output), 0644); err != nil {
-		return nil, err
-	}
-
-	return nil, nil
+	return []byte(output), nil
 }
 
 func (m *RefactorResourcesLibsTypes) down() error {
@@ -198,7 +189,7 @@ func newContentEntryUsageRule(line string) *migrationRule {
 		trigger:         patterns[icontententryTypeUsage],
 		replaceFullLine: false,
 		replacerFunc: func(string) string {
-			return "ResourceContent"
+			return "Sveltin.ResourceContent"
 		},
 	}
 }
@@ -70,7 +70,7 @@ func (m *RefactorSvelteFilesTypes) up() error {
 
 		err := afero.Walk(m.getServices().fs, m.Data.TargetPath, walkFunc)
 		if err != nil {
-			m.getServices().logger.Fatalf("Something went wrong visiting the folder %s. Are you sure it exists?", m.Data.TargetPath)
+			return fmt.Errorf("something went wrong visiting the folder %s: %w", m.Data.TargetPath, err)
 		}
 
 		migrationTriggers := []string{
@@ -90,7 +90,11 @@ func (m *RefactorSvelteFilesTypes) up() error {
 				localFilePath :=
 					strings.Replace(file, m.getServices().pathMaker.GetRootFolder(), "", 1)
 				m.getServices().logger.Info(fmt.Sprintf("Migrating %s", localFilePath))
-				if _, err := m.runMigration(fileContent, file); err != nil {
+				migratedContent, err := m.runMigration(fileContent, file)
+				if err != nil {
+					return err
+				}
+				if err := writeFile(m, file, migratedContent); err != nil {
 					return err
 				}
 			}
@@ -112,23 +116,14 @@ func (m *RefactorSvelteFilesTypes) runMigration(content []byte, file string) ([]
 			newReplaceJSONLdCurrentTitleRule(line),
 			newReplaceSvelteKitPrefetchRule(line),
 		}
-		if res, ok := applyMigrationRules(rules); ok {
+		if res, ok := applyMigrationRules(m, rules); ok {
 			lines[i] = res
 		} else {
 			lines[i] = line
 		}
 	}
 	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(file)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = afero.WriteFile(m.getServices().fs, file, []byte(output), 0644); err != nil {
-		return nil, err
-	}
-
-	return nil, nil
+	return []byte(output), nil
 }
 
 func (m *RefactorSvelteFilesTypes) down() error {
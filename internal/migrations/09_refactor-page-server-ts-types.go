@@ -70,7 +70,7 @@ func (m *RefactorPageServerTSTypes) up() error {
 
 		err := afero.Walk(m.getServices().fs, m.Data.TargetPath, walkFunc)
 		if err != nil {
-			m.getServices().logger.Fatalf("Something went wrong visiting the folder %s. Are you sure it exists?", m.Data.TargetPath)
+			return fmt.Errorf("something went wrong visiting the folder %s: %w", m.Data.TargetPath, err)
 		}
 
 		migrationTriggers := []string{
@@ -90,7 +90,11 @@ func (m *RefactorPageServerTSTypes) up() error {
 				localFilePath :=
 					strings.Replace(file, m.getServices().pathMaker.GetRootFolder(), "", 1)
 				m.getServices().logger.Info(fmt.Sprintf("Migrating %s", localFilePath))
-				if _, err := m.runMigration(fileContent, file); err != nil {
+				migratedContent, err := m.runMigration(fileContent, file)
+				if err != nil {
+					return err
+				}
+				if err := writeFile(m, file, migratedContent); err != nil {
 					return err
 				}
 			}
@@ -115,7 +119,7 @@ func (m *RefactorPageServerTSTypes) runMigration(content []byte, file string) ([
 				newPageServerTSIWebSiteUsageRule(line),
 			}
 
-			if res, ok := applyMigrationRules(rules); ok {
+			if res, ok := applyMigrationRules(m, rules); ok {
 				lines[i] = res
 			} else {
 				lines[i] = line
@@ -124,16 +128,7 @@ func (m *RefactorPageServerTSTypes) runMigration(content []byte, file string) ([
 		}
 	}
 	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(file)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = afero.WriteFile(m.getServices().fs, file, []byte(output), 0644); err != nil {
-		return nil, err
-	}
-
-	return nil, nil
+	return []byte(output), nil
 }
 
 func (m *RefactorPageServerTSTypes) down() error {
@@ -180,7 +175,7 @@ func newPageServerTSContentEntryUsageRule(line string) *migrationRule {
 		trigger:         patterns[icontententryTypeUsage],
 		replaceFullLine: false,
 		replacerFunc: func(string) string {
-			return "ResourceContent"
+			return "Sveltin.ResourceContent"
 		},
 	}
 }
@@ -11,7 +11,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/spf13/afero"
 	"github.com/sveltinio/sveltin/common"
 )
 
@@ -69,9 +68,11 @@ func (m *RefactorThemeConfig) up() error {
 			localFilePath :=
 				strings.Replace(m.Data.TargetPath, m.getServices().pathMaker.GetRootFolder(), "", 1)
 			m.getServices().logger.Info(fmt.Sprintf("Migrating %s", localFilePath))
-			if _, err := m.runMigration(fileContent, ""); err != nil {
+			migratedContent, err := m.runMigration(fileContent, "")
+			if err != nil {
 				return err
 			}
+			return writeFile(m, m.Data.TargetPath, migratedContent)
 		}
 	}
 
@@ -105,7 +106,7 @@ func (m *RefactorThemeConfig) runMigration(content []byte, filepath string) ([]b
 			newExportLineRule(line),
 			newThemeNameRule(line, prevLine),
 		}
-		if res, ok := applyMigrationRules(rules); ok {
+		if res, ok := applyMigrationRules(m, rules); ok {
 			lines[i] = res
 		} else {
 			lines[i] = line
@@ -113,15 +114,7 @@ func (m *RefactorThemeConfig) runMigration(content []byte, filepath string) ([]b
 	}
 
 	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(m.Data.TargetPath)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = afero.WriteFile(m.getServices().fs, m.Data.TargetPath, []byte(output), 0644); err != nil {
-		return nil, err
-	}
-	return nil, nil
+	return []byte(output), nil
 }
 
 //=============================================================================
@@ -8,13 +8,14 @@
 package migrations
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"regexp"
 	"strings"
 
-	"github.com/spf13/afero"
 	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/internal/jsmod"
 	"github.com/sveltinio/sveltin/utils"
 )
 
@@ -77,10 +78,11 @@ func (m *UpdateMDsveXPlugins) up() error {
 
 		if mustMigrate(fileContent, gatekeeper) && patternsMatched(fileContent, migrationTriggers, findStringMatcher) {
 			m.getServices().logger.Info(fmt.Sprintf("Migrating %s", filepath.Base(m.Data.TargetPath)))
-			updatedContent := []byte(fixRehypeAutoLinkHeadingsUsage(fileContent))
-			if _, err := m.runMigration(updatedContent, ""); err != nil {
+			migratedContent, err := m.runMigration(fileContent, m.Data.TargetPath)
+			if err != nil {
 				return err
 			}
+			return writeFile(m, m.Data.TargetPath, migratedContent)
 
 		}
 	}
@@ -103,6 +105,12 @@ func (m *UpdateMDsveXPlugins) allowUp() error {
 }
 
 func (m *UpdateMDsveXPlugins) runMigration(content []byte, file string) ([]byte, error) {
+	return editJSModule(m, content, file, updateMDsveXPlugins, m.runLinesMigration,
+		"replace remark-slug and remark-external-links with rehype-external-links by hand")
+}
+
+func (m *UpdateMDsveXPlugins) runLinesMigration(content []byte) ([]byte, error) {
+	content = fixRehypeAutoLinkHeadingsUsage(content)
 	lines := strings.Split(string(content), "\n")
 	for i, line := range lines {
 		var prevLine string
@@ -118,26 +126,66 @@ func (m *UpdateMDsveXPlugins) runMigration(content []byte, file string) ([]byte,
 			newReplaceRehypePluginUsageRule(line),
 			newReplaceRehypeSlugUsageRule(line, prevLine),
 		}
-		if res, ok := applyMigrationRules(rules); ok {
+		if res, ok := applyMigrationRules(m, rules); ok {
 			lines[i] = res
 		} else {
 			lines[i] = line
 		}
 	}
 	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(m.Data.TargetPath)
+	return []byte(output), nil
+}
+
+//=============================================================================
+
+// updateMDsveXPlugins replaces the remark-slug and remark-external-links plugins
+// with rehype-external-links and imports headings from @sveltinio/remark-headings.
+func updateMDsveXPlugins(module *jsmod.Module) error {
+	if module.RemoveImport("./src/lib/utils/headings.js") {
+		if err := module.AddImport("import headings from '@sveltinio/remark-headings';"); err != nil {
+			return err
+		}
+	}
+	module.RemoveImport("remark-slug")
+	if module.RemoveImport("remark-external-links") {
+		if err := module.AddImport("import rehypeExternalLinks from 'rehype-external-links';"); err != nil {
+			return err
+		}
+	}
+
+	isRemovedPlugin := func(element string) bool {
+		return element == "remarkSlug" || element == "remarkExternalLinks" ||
+			strings.HasPrefix(element, "[remarkExternalLinks")
+	}
+	if _, err := module.RemoveArrayElements("remarkPlugins", isRemovedPlugin); err != nil && !errors.Is(err, jsmod.ErrNotFound) {
+		return err
+	}
+
+	const autoLinkHeadings = "[rehypeAutoLinkHeadings, { behavior: 'wrap' }]"
+	const externalLinks = "[rehypeExternalLinks, { target: '_blank', rel: ['noopener', 'noreferrer'] }]"
+	elements, err := module.ArrayElements("rehypePlugins")
+	if errors.Is(err, jsmod.ErrNotFound) {
+		_, err = module.AddProperty("", "rehypePlugins", "["+externalLinks+"]", false)
+		return err
+	}
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if err = afero.WriteFile(m.getServices().fs, m.Data.TargetPath, []byte(output), 0644); err != nil {
-		return nil, err
+	isAutoLinkHeadings := func(element string) bool {
+		return strings.Contains(element, "rehypeAutoLinkHeadings") && strings.Contains(element, "'wrap'")
 	}
-	return nil, nil
+	if _, err := module.ReplaceArrayElements("rehypePlugins", isAutoLinkHeadings, autoLinkHeadings); err != nil {
+		return err
+	}
+	for _, e := range elements {
+		if strings.Contains(e, "rehypeExternalLinks") {
+			return nil
+		}
+	}
+	return module.AddArrayElement("rehypePlugins", externalLinks, false)
 }
 
-//=============================================================================
-
 func newReplaceHeadingsImportStrRule(line string) *migrationRule {
 	return &migrationRule{
 		value:           line,
@@ -245,5 +293,5 @@ func fixRehypeAutoLinkHeadingsUsage(content []byte) []byte {
 		return []byte(updatedContent)
 	}
 
-	return []byte("")
+	return content
 }
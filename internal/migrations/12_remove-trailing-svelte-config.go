@@ -12,8 +12,8 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/spf13/afero"
 	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/internal/jsmod"
 )
 
 // RemoveTrailingFromSvelteConfig is the struct representing the migration update the defaults.js.ts file.
@@ -69,9 +69,11 @@ func (m *RemoveTrailingFromSvelteConfig) up() error {
 		}
 		if patternsMatched(fileContent, migrationTriggers, findStringMatcher) {
 			m.getServices().logger.Info(fmt.Sprintf("Migrating %s", filepath.Base(m.Data.TargetPath)))
-			if _, err := m.runMigration(fileContent, ""); err != nil {
+			migratedContent, err := m.runMigration(fileContent, m.Data.TargetPath)
+			if err != nil {
 				return err
 			}
+			return writeFile(m, m.Data.TargetPath, migratedContent)
 		}
 	}
 
@@ -93,32 +95,39 @@ func (m *RemoveTrailingFromSvelteConfig) allowUp() error {
 }
 
 func (m *RemoveTrailingFromSvelteConfig) runMigration(content []byte, file string) ([]byte, error) {
+	return editJSModule(m, content, file, removeTrailingSlash, m.runLinesMigration,
+		"remove kit.trailingSlash and kit.prerender.enabled by hand")
+}
+
+func (m *RemoveTrailingFromSvelteConfig) runLinesMigration(content []byte) ([]byte, error) {
 	lines := strings.Split(string(content), "\n")
 	for i, line := range lines {
 		rules := []*migrationRule{
 			newSvelteConfigTrailingSlashRule(line),
 			newSvelteConfigPrerenderEnabledRule(line),
 		}
-		if res, ok := applyMigrationRules(rules); ok {
+		if res, ok := applyMigrationRules(m, rules); ok {
 			lines[i] = res
 		} else {
 			lines[i] = line
 		}
 	}
 	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(m.Data.TargetPath)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = afero.WriteFile(m.getServices().fs, m.Data.TargetPath, []byte(output), 0644); err != nil {
-		return nil, err
-	}
-	return nil, nil
+	return []byte(output), nil
 }
 
 //=============================================================================
 
+// removeTrailingSlash removes kit.trailingSlash and kit.prerender.enabled.
+func removeTrailingSlash(module *jsmod.Module) error {
+	for _, prop := range []string{"kit.trailingSlash", "kit.prerender.enabled"} {
+		if _, err := module.RemoveProperty(prop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func newSvelteConfigTrailingSlashRule(line string) *migrationRule {
 	return &migrationRule{
 		value:           line,
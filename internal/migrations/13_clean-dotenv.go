@@ -13,7 +13,6 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/spf13/afero"
 	"github.com/sveltinio/sveltin/common"
 )
 
@@ -71,9 +70,11 @@ func (m *CleanDotEnv) up() error {
 		}
 		if patternsMatched(fileContent, migrationTriggers, findStringMatcher) {
 			m.getServices().logger.Info(fmt.Sprintf("Migrating %s", filepath.Base(m.Data.TargetPath)))
-			if _, err := m.runMigration(fileContent, ""); err != nil {
+			migratedContent, err := m.runMigration(fileContent, "")
+			if err != nil {
 				return err
 			}
+			return writeFile(m, m.Data.TargetPath, migratedContent)
 		}
 	}
 
@@ -102,23 +103,14 @@ func (m *CleanDotEnv) runMigration(content []byte, file string) ([]byte, error)
 			newDotEnvSvelteKitBuildCommentRule(line),
 			newDotEnvSveltekitRule(line),
 		}
-		if res, ok := applyMigrationRules(rules); ok {
+		if res, ok := applyMigrationRules(m, rules); ok {
 			lines[i] = res
 		} else {
 			lines[i] = line
 		}
 	}
 	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(m.Data.TargetPath)
-	if err != nil {
-		return nil, err
-	}
-
-	cleanedOutput := removeMultiEmptyLines(output)
-	if err = afero.WriteFile(m.getServices().fs, m.Data.TargetPath, cleanedOutput, 0644); err != nil {
-		return nil, err
-	}
-	return nil, nil
+	return removeMultiEmptyLines(output), nil
 }
 
 //=============================================================================
@@ -158,8 +150,12 @@ func newDotEnvSvelteKitBuildCommentRule(line string) *migrationRule {
 
 // =============================================================================
 
+// removeMultiEmptyLines collapses consecutive empty lines and keeps the trailing new line.
 func removeMultiEmptyLines(content string) []byte {
-	rule := regexp.MustCompile(`^\n{1,}$`)
-	output := rule.ReplaceAllString(strings.TrimSpace(content), "\n")
-	return []byte(output)
+	rule := regexp.MustCompile(`\n{3,}`)
+	output := rule.ReplaceAllString(strings.TrimSpace(content), "\n\n")
+	if output == "" {
+		return []byte(output)
+	}
+	return []byte(output + "\n")
 }
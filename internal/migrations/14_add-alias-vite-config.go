@@ -12,8 +12,8 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/spf13/afero"
 	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/internal/jsmod"
 )
 
 // AddAliasToViteConfig is the struct representing the migration update the defaults.js.ts file.
@@ -64,13 +64,13 @@ func (m *AddAliasToViteConfig) up() error {
 		}
 
 		gatekeeper := "./src/sveltin"
-		migrationTriggers := []string{patterns[viteAlias]}
-		if mustMigrate(fileContent, gatekeeper) &&
-			patternsMatched(fileContent, migrationTriggers, findStringMatcher) {
+		if mustMigrate(fileContent, gatekeeper) {
 			m.getServices().logger.Info(fmt.Sprintf("Migrating %s", filepath.Base(m.Data.TargetPath)))
-			if _, err := m.runMigration(fileContent, ""); err != nil {
+			migratedContent, err := m.runMigration(fileContent, m.Data.TargetPath)
+			if err != nil {
 				return err
 			}
+			return writeFile(m, m.Data.TargetPath, migratedContent)
 		}
 	}
 
@@ -92,32 +92,56 @@ func (m *AddAliasToViteConfig) allowUp() error {
 }
 
 func (m *AddAliasToViteConfig) runMigration(content []byte, file string) ([]byte, error) {
+	return editJSModule(m, content, file, addSveltinAlias, m.runLinesMigration,
+		"add the $sveltin alias for './src/sveltin' to resolve.alias by hand")
+}
+
+func (m *AddAliasToViteConfig) runLinesMigration(content []byte) ([]byte, error) {
 	lines := strings.Split(string(content), "\n")
 	for i, line := range lines {
 		rules := []*migrationRule{
 			newViteConfigRule(line),
 		}
-		if res, ok := applyMigrationRules(rules); ok {
+		if res, ok := applyMigrationRules(m, rules); ok {
 			lines[i] = res
 		} else {
 			lines[i] = line
 		}
 	}
 	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(m.Data.TargetPath)
-	if err != nil {
-		return nil, err
+	return removeMultiEmptyLines(output), nil
+}
+
+//=============================================================================
+
+// addSveltinAlias adds the $sveltin alias to resolve.alias, resolving the path the same way
+// the other aliases do (e.g. path.resolve('./config') or resolve(join(__dirname, './config'))).
+func addSveltinAlias(module *jsmod.Module) error {
+	value := ""
+	if configAlias, err := module.Property("resolve.alias.$config"); err == nil && strings.Contains(configAlias, "./config") {
+		value = strings.Replace(configAlias, "./config", "./src/sveltin", 1)
+	} else if imp := module.FindImport("path"); imp != nil && imp.Default != "" {
+		value = fmt.Sprintf("%s.resolve('./src/sveltin')", imp.Default)
+	} else {
+		if err := module.AddImport("import path from 'path';"); err != nil {
+			return err
+		}
+		value = "path.resolve('./src/sveltin')"
 	}
 
-	cleanedOutput := removeMultiEmptyLines(output)
-	if err = afero.WriteFile(m.getServices().fs, m.Data.TargetPath, cleanedOutput, 0644); err != nil {
-		return nil, err
+	switch {
+	case module.HasProperty("resolve.alias"):
+		_, err := module.AddProperty("resolve.alias", "$sveltin", value, true)
+		return err
+	case module.HasProperty("resolve"):
+		_, err := module.AddProperty("resolve", "alias", fmt.Sprintf("{ $sveltin: %s }", value), true)
+		return err
+	default:
+		_, err := module.AddProperty("", "resolve", fmt.Sprintf("{ alias: { $sveltin: %s } }", value), false)
+		return err
 	}
-	return nil, nil
 }
 
-//=============================================================================
-
 func newViteConfigRule(line string) *migrationRule {
 	return &migrationRule{
 		value:           line,
@@ -115,7 +115,7 @@ func (m *UpdatePackageJson) up() error {
 		}
 
 		// save new package.json file
-		if err = overwriteFile(m, updatedContent); err != nil {
+		if err = writeFile(m, m.Data.TargetPath, updatedContent); err != nil {
 			return err
 		}
 	}
@@ -146,7 +146,7 @@ func (m *UpdatePackageJson) runMigration(content []byte, file string) ([]byte, e
 			newRemoveMdastUtilToString(line),
 			newRemoveUnistUtilVisit(line),
 		}
-		if res, ok := applyMigrationRules(rules); ok {
+		if res, ok := applyMigrationRules(m, rules); ok {
 			lines[i] = res
 		} else {
 			lines[i] = line
@@ -164,7 +164,7 @@ func newRemarkExternalLinksRule(line string) *migrationRule {
 		trigger:         patterns[remarkExtLinks],
 		replaceFullLine: true,
 		replacerFunc: func(string) string {
-			return "\"rehype-external-links\":\"^2.0.1\","
+			return replaceDependencyLine(line, "rehype-external-links", "^2.0.1")
 		},
 	}
 }
@@ -175,7 +175,7 @@ func newRemarkSlugRule(line string) *migrationRule {
 		trigger:         patterns[remarkSlug],
 		replaceFullLine: true,
 		replacerFunc: func(string) string {
-			return "\"@sveltinio/remark-headings\":\"^1.0.1\","
+			return replaceDependencyLine(line, "@sveltinio/remark-headings", "^1.0.1")
 		},
 	}
 }
@@ -202,6 +202,17 @@ func newRemoveUnistUtilVisit(line string) *migrationRule {
 	}
 }
 
+// replaceDependencyLine returns the dependency line for name and version keeping the
+// indentation and the trailing comma of the line it replaces.
+func replaceDependencyLine(line, name, version string) string {
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	newLine := fmt.Sprintf("%s\"%s\": \"%s\"", indent, name, version)
+	if strings.HasSuffix(strings.TrimSpace(line), ",") {
+		newLine += ","
+	}
+	return newLine
+}
+
 //=============================================================================
 
 func updateDevDependency(m *UpdatePackageJson, content []byte, name, value string) ([]byte, error) {
@@ -8,21 +8,25 @@
 package migrations
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/spf13/afero"
 )
 
-// UnhandledMigration is the struct representing the migration update the defaults.js.ts file.
+// UnhandledMigration is the struct representing the migration rewriting imports and props
+// for the @sveltinio/* components, following the sveltinioComponentChanges mapping table.
+// Constructs which cannot be safely rewritten are reported as warnings.
 type UnhandledMigration struct {
 	Mediator IMigrationMediator
 	Services *MigrationServices
 	Data     *MigrationData
+
+	componentChanges map[string][]*sveltinioComponentChange
 }
 
 // MakeMigration implements IMigrationFactory interface,
@@ -64,28 +68,29 @@ func (m *UnhandledMigration) up() error {
 		return err
 	}
 
-	// @sveltinio/essentials min version
-	const minEssentialsVersion = 0.5
-	// Retrieve @sveltinio/essentials version
-	currentEssentialsVersion := retrievePackageVersionNumber(fileContent, "@sveltinio/essentials")
-
-	// @sveltinio/seo min version
-	const minSeoVersion = 0.3
-	// Retrieve @sveltinio/essentials version
-	currentSeoVersion := retrievePackageVersionNumber(fileContent, "@sveltinio/seo")
+	// Pick the changes from the mapping table for the installed @sveltinio/* packages.
+	componentChanges := map[string][]*sveltinioComponentChange{}
+	for _, name := range []string{"@sveltinio/essentials", "@sveltinio/seo", "@sveltinio/widgets"} {
+		currentVersion, ok := retrievePackageVersion(fileContent, name)
+		if !ok {
+			continue
+		}
+		nextVersion := patternsFindString(npmPackagesMap[name], semVersion)
+		changes, err := getSveltinioComponentChanges(name, currentVersion, nextVersion)
+		if err != nil {
+			return err
+		}
+		if len(changes) > 0 {
+			componentChanges[name] = changes
+		}
+	}
 
-	// @sveltinio/widgets min version
-	const minWidgetsVersion = 0.5
-	// Retrieve @sveltinio/widgets version
-	currentWidgetsVersion := retrievePackageVersionNumber(fileContent, "@sveltinio/widgets")
+	m.componentChanges = componentChanges
 
-	if exists &&
-		(isPreviousVersion(currentEssentialsVersion, minEssentialsVersion) ||
-			isPreviousVersion(currentSeoVersion, minSeoVersion) ||
-			isPreviousVersion(currentWidgetsVersion, minWidgetsVersion)) {
+	if exists && len(componentChanges) > 0 {
 		files := []string{}
 		walkFunc := func(file string, info os.FileInfo, err error) error {
-			if filepath.Ext(file) == ".svelte" {
+			if ext := filepath.Ext(file); ext == ".svelte" || ext == ".svx" {
 				files = append(files, file)
 			}
 			return nil
@@ -93,11 +98,12 @@ func (m *UnhandledMigration) up() error {
 
 		err := afero.Walk(m.getServices().fs, m.Data.TargetPath, walkFunc)
 		if err != nil {
-			m.getServices().logger.Fatalf("Something went wrong visiting the folder %s. Are you sure it exists?", m.Data.TargetPath)
+			return fmt.Errorf("something went wrong visiting the folder %s: %w", m.Data.TargetPath, err)
 		}
 
 		migrationTriggers := []string{
 			patterns[essentialsImport],
+			patterns[seoImport],
 			patterns[widgetsImport],
 		}
 
@@ -108,13 +114,15 @@ func (m *UnhandledMigration) up() error {
 			}
 
 			if patternsMatched(fileContent, migrationTriggers, findStringMatcher) {
-				if !bytes.Contains(fileContent, []byte("[sveltin migrate] @IMPORTANT")) {
-					localFilePath :=
-						strings.Replace(file, m.getServices().pathMaker.GetRootFolder(), "", 1)
-					m.getServices().logger.Info(fmt.Sprintf("Migrating %s", localFilePath))
-					if _, err := m.runMigration(fileContent, file); err != nil {
-						return err
-					}
+				localFilePath :=
+					strings.Replace(file, m.getServices().pathMaker.GetRootFolder(), "", 1)
+				m.getServices().logger.Info(fmt.Sprintf("Migrating %s", localFilePath))
+				migratedContent, err := m.runMigration(fileContent, file)
+				if err != nil {
+					return err
+				}
+				if err := writeFile(m, file, migratedContent); err != nil {
+					return err
 				}
 			}
 		}
@@ -124,36 +132,6 @@ func (m *UnhandledMigration) up() error {
 	return nil
 }
 
-func (m *UnhandledMigration) runMigration(content []byte, file string) ([]byte, error) {
-
-	lines := strings.Split(string(content), "\n")
-
-	for i, line := range lines {
-		rules := []*migrationRule{
-			newEssentialsImportRule(line),
-			newSeoImportRule(line),
-			newWidgetsImportRule(line),
-		}
-		if res, ok := applyMigrationRules(rules); ok {
-			lines[i] = res
-		} else {
-			lines[i] = line
-		}
-	}
-
-	output := strings.Join(lines, "\n")
-	err := m.getServices().fs.Remove(file)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = afero.WriteFile(m.getServices().fs, file, []byte(output), 0644); err != nil {
-		return nil, err
-	}
-
-	return nil, nil
-}
-
 func (m *UnhandledMigration) down() error {
 	if err := m.Mediator.notifyAboutCompletion(); err != nil {
 		return err
@@ -168,97 +146,35 @@ func (m *UnhandledMigration) allowUp() error {
 	return nil
 }
 
-//=============================================================================
-
-func newEssentialsImportRule(line string) *migrationRule {
-	return &migrationRule{
-		value:           line,
-		trigger:         patterns[essentialsImport],
-		replaceFullLine: true,
-		replacerFunc: func(string) string {
-			message := `
-	/**
-	 * ! [sveltin migrate] @IMPORTANT
-	 * We detected usage of components from @sveltinio/essentials.
-	 *
-	 * Latest versions of the package introduced changes the components interfaces.
-	 *
-	 * Check the updated documentation page and reflect the changes:
-	 * https://github.com/sveltinio/components-library/tree/main/packages/essentials
-	 */
-`
-			var sb strings.Builder
-			sb.WriteString(message)
-			sb.WriteString(line)
-			return sb.String()
-		},
-	}
-}
-
-func newSeoImportRule(line string) *migrationRule {
-	return &migrationRule{
-		value:           line,
-		trigger:         patterns[essentialsImport],
-		replaceFullLine: true,
-		replacerFunc: func(string) string {
-			message := `
-	/**
-	 * ! [sveltin migrate] @IMPORTANT
-	 * We detected usage of components from @sveltinio/seo.
-	 *
-	 * Latest versions of the package introduced changes the components interfaces.
-	 *
-	 * Check the updated documentation page and reflect the changes:
-	 * https://github.com/sveltinio/components-library/tree/main/packages/seo
-	 */
-`
-			var sb strings.Builder
-			sb.WriteString(message)
-			sb.WriteString(line)
-			return sb.String()
-		},
+func (m *UnhandledMigration) runMigration(content []byte, file string) ([]byte, error) {
+	output, warnings := rewriteSveltinioComponents(string(content), m.componentChanges)
+	if output != string(content) {
+		for _, changes := range m.componentChanges {
+			for _, c := range changes {
+				if strings.Contains(string(content), c.component) {
+					recordRule(m, fmt.Sprintf("%s@%s %s", c.pkg, c.since, c.component))
+				}
+			}
+		}
 	}
-}
-
-func newWidgetsImportRule(line string) *migrationRule {
-	return &migrationRule{
-		value:           line,
-		trigger:         patterns[widgetsImport],
-		replaceFullLine: true,
-		replacerFunc: func(string) string {
-			message := `
-	/**
-	 * ! [sveltin migrate] @IMPORTANT
-	 * We detected usage of components from @sveltinio/widgets.
-	 *
-	 * Latest versions of the package introduced changes the components interfaces.
-	 *
-	 * Check the updated documentation page and reflect the changes:
-	 * https://github.com/sveltinio/components-library/tree/main/packages/widgets
-	 */
-`
-			var sb strings.Builder
-			sb.WriteString(message)
-			sb.WriteString(line)
-			return sb.String()
-		},
+	for _, w := range warnings {
+		addWarning(m, file, w.line, w.message)
 	}
+	return []byte(output), nil
 }
 
 //=============================================================================
 
-func retrievePackageVersionNumber(content []byte, name string) float64 {
+// retrievePackageVersion returns the version number (e.g. 0.5.2) for the package in package.json devDependencies.
+func retrievePackageVersion(content []byte, name string) (string, bool) {
 	currentVersionStr, res := getDevDependency(content, name)
-	var currentVersion float64
-	if res {
-		currentVersion, _ = versionAsNum(currentVersionStr)
+	if !res {
+		return "", false
 	}
-	return currentVersion
+	version := patternsFindString(currentVersionStr, semVersion)
+	return version, version != ""
 }
 
-func isPreviousVersion(current, target float64) bool {
-	if current == 0 {
-		return false
-	}
-	return current < target
+func patternsFindString(text string, id migrationTriggerId) string {
+	return regexp.MustCompile(patterns[id]).FindString(text)
 }
@@ -0,0 +1,96 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package migrations
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/sveltinio/sveltin/internal/pathmaker"
+)
+
+// TargetsFunc returns the path to the file or folder each migration works on.
+// It is called once the project settings file has been created or updated.
+type TargetsFunc func() (map[Migration]string, error)
+
+// TargetsMap returns the path to the file or folder each migration works on for the project
+// within the root folder. themeConfigFile is the name of the config file of the theme.
+func TargetsMap(root string, pathMaker *pathmaker.SveltinPathMaker, themeName, themeConfigFile string) map[Migration]string {
+	return map[Migration]string{
+		ProjectSettings:          filepath.Join(root, "sveltin.json"),
+		DefaultsConfig:           filepath.Join(root, pathMaker.GetConfigFolder(), "defaults.js.ts"),
+		WebSiteTS:                filepath.Join(root, pathMaker.GetConfigFolder(), "website.js.ts"),
+		MenuTS:                   filepath.Join(root, pathMaker.GetConfigFolder(), "menu.js.ts"),
+		SveltinDTS:               filepath.Join(root, pathMaker.GetSrcFolder(), "sveltin.d.ts"),
+		ResourceLibs:             filepath.Join(root, pathMaker.GetLibFolder()),
+		Layout:                   filepath.Join(root, pathMaker.GetRoutesFolder(), "+layout.ts"),
+		SvelteFiles:              filepath.Join(root, pathMaker.GetRoutesFolder()),
+		PageServerTS:             filepath.Join(root, pathMaker.GetRoutesFolder()),
+		SveltinioComponent:       filepath.Join(root, pathMaker.GetRoutesFolder()),
+		ThemeConfig:              filepath.Join(root, pathMaker.GetThemesFolder(), themeName, themeConfigFile),
+		ThemeSveltinioComponents: filepath.Join(root, pathMaker.GetThemesFolder()),
+		MDsveXConfig:             filepath.Join(root, "mdsvex.config.js"),
+		SvelteConfig:             filepath.Join(root, "svelte.config.js"),
+		DotEnv:                   filepath.Join(root, ".env.production"),
+		ViteConfig:               filepath.Join(root, "vite.config.ts"),
+		TSConfig:                 filepath.Join(root, "tsconfig.json"),
+		PackageJSON:              filepath.Join(root, "package.json"),
+	}
+}
+
+// RunChain runs the ProjectSettings migration and then the migrations to apply when upgrading
+// the project from projectVersion to cliVersion, sorted by version.
+// It returns the ids of the executed migrations so that they can be recorded.
+func RunChain(migrationManager *MigrationManager, services *MigrationServices, pathToSettingsFile, projectVersion, cliVersion string, targets TargetsFunc) ([]Migration, error) {
+	migrationData := &MigrationData{
+		ID:                ProjectSettings,
+		TargetPath:        pathToSettingsFile,
+		CliVersion:        cliVersion,
+		ProjectCliVersion: projectVersion,
+	}
+	if err := runChainMigration(migrationManager, services, migrationData); err != nil {
+		return nil, err
+	}
+
+	targetsMap, err := targets()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := GetAppliedMigrations(services.fs, pathToSettingsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	migrationIds, err := GetMigrationsToApply(projectVersion, cliVersion, applied)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range migrationIds {
+		pathToTarget, ok := targetsMap[id]
+		if !ok {
+			return nil, fmt.Errorf("no target path for the migration: %s", id)
+		}
+		migrationData := &MigrationData{
+			ID:         id,
+			TargetPath: pathToTarget,
+		}
+		if err := runChainMigration(migrationManager, services, migrationData); err != nil {
+			return nil, err
+		}
+	}
+	return migrationIds, nil
+}
+
+func runChainMigration(migrationManager *MigrationManager, services *MigrationServices, data *MigrationData) error {
+	migrationFactory, err := GetMigrationFactory(data.ID)
+	if err != nil {
+		return err
+	}
+	migration := migrationFactory.MakeMigration(migrationManager, services, data)
+	return services.Run(migration)
+}
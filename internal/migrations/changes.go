@@ -0,0 +1,50 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package migrations
+
+import "fmt"
+
+// FileChange is the struct representing a change made by a migration to a file.
+type FileChange struct {
+	Migration string
+	Path      string
+	Before    []byte
+	After     []byte
+	IsNew     bool
+}
+
+// GroupChangesByMigration returns the changes grouped by migration name
+// and the migration names in execution order.
+func GroupChangesByMigration(changes []*FileChange) ([]string, map[string][]*FileChange) {
+	names := []string{}
+	groups := map[string][]*FileChange{}
+	for _, c := range changes {
+		if _, exists := groups[c.Migration]; !exists {
+			names = append(names, c.Migration)
+		}
+		groups[c.Migration] = append(groups[c.Migration], c)
+	}
+	return names, groups
+}
+
+// Warning is the struct representing something a migration could not change safely
+// and must be checked by hand.
+type Warning struct {
+	Migration string
+	Path      string
+	Line      int
+	Message   string
+}
+
+// String returns the warning as path:line: message.
+func (w *Warning) String() string {
+	if w.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", w.Path, w.Line, w.Message)
+	}
+	return fmt.Sprintf("%s: %s", w.Path, w.Message)
+}
@@ -50,11 +50,17 @@ var migrationNameMap = map[Migration]string{
 	MDsveXConfig:             "mdsvex-config-js",
 	SvelteConfig:             "svelte-config-js",
 	DotEnv:                   "dotenv",
-	ViteConfig:               "vire-config-ts",
+	ViteConfig:               "vite-config-ts",
 	TSConfig:                 "ts-config-ts",
 	PackageJSON:              "package-json",
 }
 
+// legacyMigrationNameMap maps the names used by older sveltin versions, and possibly
+// recorded as applied in the project settings file, to the migration ids.
+var legacyMigrationNameMap = map[string]Migration{
+	"vire-config-ts": ViteConfig,
+}
+
 var migrationMap = map[Migration]IMigrationFactory{
 	ProjectSettings:          &AddUpdateProjectSettings{},
 	DefaultsConfig:           &RefactorDefaultsTSTypes{},
@@ -76,6 +82,44 @@ var migrationMap = map[Migration]IMigrationFactory{
 	PackageJSON:              &UpdatePackageJson{},
 }
 
+// String returns the migration name.
+func (m Migration) String() string {
+	if name, exists := migrationNameMap[m]; exists {
+		return name
+	}
+	return fmt.Sprintf("migration-%d", int(m))
+}
+
+// versionRange is the range of project versions a migration applies to:
+// projects created with sveltin >= from (no lower bound when empty) and < to.
+// Once applied, the project is migrated to the sveltin version to.
+type versionRange struct {
+	from string
+	to   string
+}
+
+// migrationVersionMap defines the version range for each migration but ProjectSettings,
+// which always runs to bump the sveltin version in the project settings file.
+var migrationVersionMap = map[Migration]versionRange{
+	DefaultsConfig:           {to: "0.11.0"},
+	WebSiteTS:                {to: "0.11.0"},
+	MenuTS:                   {to: "0.11.0"},
+	SveltinDTS:               {to: "0.11.0"},
+	ResourceLibs:             {to: "0.11.0"},
+	Layout:                   {to: "0.11.0"},
+	SvelteFiles:              {to: "0.11.0"},
+	PageServerTS:             {to: "0.11.0"},
+	SveltinioComponent:       {to: "0.11.0"},
+	ThemeConfig:              {to: "0.11.0"},
+	ThemeSveltinioComponents: {to: "0.11.0"},
+	MDsveXConfig:             {to: "0.11.0"},
+	SvelteConfig:             {to: "0.11.0"},
+	DotEnv:                   {to: "0.11.0"},
+	ViteConfig:               {to: "0.11.0"},
+	TSConfig:                 {to: "0.11.0"},
+	PackageJSON:              {to: "0.11.0"},
+}
+
 // IMigrationFactory declares a set of methods for creating each of the abstract migrations.
 type IMigrationFactory interface {
 	MakeMigration(*MigrationManager, *MigrationServices, *MigrationData) IMigration
@@ -0,0 +1,171 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package migrations
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/pathmaker"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// Each folder in testdata/fixtures is a project created with an older sveltin version.
+// The input folder holds the project files, the expected folder the files after the migration.
+// Run "go test ./internal/migrations -run TestMigrationFixtures -update" to regenerate the
+// expected folders after adding a fixture or changing a migration, then review the diff.
+var updateFixtures = flag.Bool("update", false, "update the expected files of the migration fixtures")
+
+const (
+	fixturesFolder      = "testdata/fixtures"
+	fixturesCliVersion  = "0.11.0"
+	projectSettingsFile = "sveltin.json"
+)
+
+func TestMigrationFixtures(t *testing.T) {
+	is := is.New(t)
+
+	fixtures, err := os.ReadDir(fixturesFolder)
+	is.NoErr(err)
+	is.True(len(fixtures) > 0)
+
+	for _, fixture := range fixtures {
+		if !fixture.IsDir() {
+			continue
+		}
+		folder := filepath.Join(fixturesFolder, fixture.Name())
+		t.Run(fixture.Name(), func(t *testing.T) {
+			testMigrationFixture(t, folder)
+		})
+	}
+}
+
+func testMigrationFixture(t *testing.T, folder string) {
+	is := is.New(t)
+
+	pathMaker := newTestPathMaker(t)
+	// the path maker resolves the project root to the current directory.
+	root := pathMaker.GetRootFolder()
+	memFs := afero.NewMemMapFs()
+	input, err := readFixtureTree(afero.NewOsFs(), filepath.Join(folder, "input"))
+	is.NoErr(err)
+	for name, content := range input {
+		is.NoErr(afero.WriteFile(memFs, filepath.Join(root, name), content, 0644))
+	}
+	projectVersion := gjson.GetBytes(input[projectSettingsFile], "sveltin.version").String()
+
+	migrationIds := runFixtureMigrations(t, memFs, pathMaker, projectVersion)
+	got, err := readFixtureTree(memFs, root)
+	is.NoErr(err)
+
+	expectedFolder := filepath.Join(folder, "expected")
+	if *updateFixtures {
+		is.NoErr(os.RemoveAll(expectedFolder))
+		for name, content := range got {
+			is.NoErr(os.MkdirAll(filepath.Join(expectedFolder, filepath.Dir(name)), 0755))
+			is.NoErr(os.WriteFile(filepath.Join(expectedFolder, name), content, 0644))
+		}
+		return
+	}
+	expected, err := readFixtureTree(afero.NewOsFs(), expectedFolder)
+	is.NoErr(err)
+	compareFixtureTrees(t, expected, got)
+
+	// Running the migrations again, even when not recorded as applied, must not change anything.
+	if len(migrationIds) > 0 {
+		pathToSettingsFile := filepath.Join(root, projectSettingsFile)
+		content, err := afero.ReadFile(memFs, pathToSettingsFile)
+		is.NoErr(err)
+		content, err = sjson.DeleteBytes(content, appliedMigrationsKey)
+		is.NoErr(err)
+		is.NoErr(afero.WriteFile(memFs, pathToSettingsFile, content, 0644))
+	}
+
+	runFixtureMigrations(t, memFs, pathMaker, projectVersion)
+	got, err = readFixtureTree(memFs, root)
+	is.NoErr(err)
+	compareFixtureTrees(t, expected, got)
+}
+
+// runFixtureMigrations runs the migrations chain and returns the ids of the applied migrations.
+func runFixtureMigrations(t *testing.T, memFs afero.Fs, pathMaker *pathmaker.SveltinPathMaker, projectVersion string) []Migration {
+	is := is.New(t)
+
+	services := newTestServices(memFs, pathMaker)
+
+	root := pathMaker.GetRootFolder()
+	pathToSettingsFile := filepath.Join(root, projectSettingsFile)
+	migrationIds, err := RunChain(NewMigrationManager(), services, pathToSettingsFile, projectVersion, fixturesCliVersion,
+		func() (map[Migration]string, error) {
+			content, err := afero.ReadFile(memFs, pathToSettingsFile)
+			if err != nil {
+				return nil, err
+			}
+			return TargetsMap(root, pathMaker, gjson.GetBytes(content, "theme.name").String(), "theme.config.js"), nil
+		})
+	is.NoErr(err)
+	is.NoErr(RecordAppliedMigrations(services, pathToSettingsFile, migrationIds))
+	return migrationIds
+}
+
+// readFixtureTree returns the content of the files within the folder by their relative paths.
+func readFixtureTree(afs afero.Fs, folder string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := afero.Walk(afs, folder, func(file string, info fs.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := afero.ReadFile(afs, file)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(folder, file)
+		if err != nil {
+			return err
+		}
+		files[name] = content
+		return nil
+	})
+	return files, err
+}
+
+func compareFixtureTrees(t *testing.T, expected, got map[string][]byte) {
+	t.Helper()
+
+	names := []string{}
+	for name := range expected {
+		names = append(names, name)
+	}
+	for name := range got {
+		if _, ok := expected[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		want, inExpected := expected[name]
+		have, inGot := got[name]
+		switch {
+		case !inGot:
+			t.Errorf("%s: missing file", name)
+		case !inExpected:
+			t.Errorf("%s: unexpected file", name)
+		case !bytes.Equal(want, have):
+			t.Errorf("%s: content mismatch\n--- expected\n%s\n--- got\n%s", name, want, have)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package migrations
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/content"
+	"gopkg.in/yaml.v3"
+)
+
+// Actions for the frontmatter rules.
+const (
+	FrontmatterRename  = "rename"
+	FrontmatterMove    = "move"
+	FrontmatterDefault = "default"
+	FrontmatterDelete  = "delete"
+)
+
+// FrontmatterMigration is the struct representing a migration for the frontmatter of the content files.
+//
+// The migration applies to all the content/<resource>/<slug>/index.svx files,
+// or to the ones for the listed Resources only.
+type FrontmatterMigration struct {
+	Name      string             `mapstructure:"name" validate:"required"`
+	Resources []string           `mapstructure:"resources"`
+	Rules     []*FrontmatterRule `mapstructure:"rules" validate:"required,dive"`
+}
+
+// FrontmatterRule is the struct representing a rule for a frontmatter migration.
+// Key and To are dot-separated paths to the frontmatter keys (e.g. misc.author).
+//
+//   - rename: renames Key to To (the last path segment only), keeping its position;
+//   - move: moves Key to the To path, creating the missing parent keys;
+//   - default: sets Key to Value when missing;
+//   - delete: deletes Key.
+type FrontmatterRule struct {
+	Action string      `mapstructure:"action" validate:"required,oneof=rename move default delete"`
+	Key    string      `mapstructure:"key" validate:"required"`
+	To     string      `mapstructure:"to" validate:"required_if=Action rename,required_if=Action move"`
+	Value  interface{} `mapstructure:"value"`
+}
+
+//=============================================================================
+
+// ApplyFrontmatterMigration is the struct representing the migration running a frontmatter migration.
+type ApplyFrontmatterMigration struct {
+	Mediator   IMigrationMediator
+	Services   *MigrationServices
+	Data       *MigrationData
+	Definition *FrontmatterMigration
+}
+
+// NewFrontmatterMigration returns the migration for the frontmatter migration. TargetPath for data is the content folder.
+func NewFrontmatterMigration(migrationManager *MigrationManager, services *MigrationServices, data *MigrationData, definition *FrontmatterMigration) IMigration {
+	data.Name = definition.Name
+	return &ApplyFrontmatterMigration{
+		Mediator:   migrationManager,
+		Services:   services,
+		Data:       data,
+		Definition: definition,
+	}
+}
+
+// implements IMigration interface.
+func (m *ApplyFrontmatterMigration) getServices() *MigrationServices { return m.Services }
+func (m *ApplyFrontmatterMigration) getData() *MigrationData         { return m.Data }
+
+// Migrate return error if migration execution over up and down methods fails (IMigration interface).
+func (m ApplyFrontmatterMigration) Migrate() error {
+	if err := m.up(); err != nil {
+		return err
+	}
+	if err := m.down(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ApplyFrontmatterMigration) up() error {
+	if !m.Mediator.canRun(m) {
+		return nil
+	}
+
+	exists, err := afero.DirExists(m.getServices().fs, m.Data.TargetPath)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	// content/<resource>/<slug>/index.svx
+	files, err := afero.Glob(m.getServices().fs, filepath.Join(m.Data.TargetPath, "*", "*", "index.svx"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if !m.isSelectedResource(file) {
+			continue
+		}
+
+		fileContent, err := retrieveFileContent(m.getServices().fs, file)
+		if err != nil {
+			return err
+		}
+
+		migratedContent, err := m.runMigration(fileContent, file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if !bytes.Equal(fileContent, migratedContent) {
+			localFilePath :=
+				strings.Replace(file, m.getServices().pathMaker.GetRootFolder(), "", 1)
+			m.getServices().logger.Info(fmt.Sprintf("Migrating %s (%s)", localFilePath, m.Definition.Name))
+			if err := writeFile(m, file, migratedContent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ApplyFrontmatterMigration) down() error {
+	if err := m.Mediator.notifyAboutCompletion(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ApplyFrontmatterMigration) allowUp() error {
+	if err := m.up(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ApplyFrontmatterMigration) runMigration(content []byte, file string) ([]byte, error) {
+	fm, err := parseFrontmatter(content)
+	if err != nil {
+		return nil, err
+	}
+	if fm == nil {
+		return content, nil
+	}
+
+	changed := false
+	for _, r := range m.Definition.Rules {
+		done, err := applyFrontmatterRule(fm.mapping, r)
+		if err != nil {
+			addWarning(m, file, 0, err.Error())
+			continue
+		}
+		if done {
+			recordRule(m, fmt.Sprintf("%s %s", r.Action, r.Key))
+			changed = true
+		}
+	}
+	if !changed {
+		return content, nil
+	}
+
+	return fm.bytes()
+}
+
+func (m *ApplyFrontmatterMigration) isSelectedResource(file string) bool {
+	if len(m.Definition.Resources) == 0 {
+		return true
+	}
+	resource := filepath.Base(filepath.Dir(filepath.Dir(file)))
+	for _, r := range m.Definition.Resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+//=============================================================================
+
+// frontmatter is the YAML frontmatter of a content file, along with the bytes around it.
+type frontmatter struct {
+	doc     *yaml.Node
+	mapping *yaml.Node
+	newline string
+	// head is the content up to the YAML (byte order mark and opening delimiter),
+	// tail the content after it (closing delimiter and body).
+	head []byte
+	tail []byte
+}
+
+// parseFrontmatter returns the frontmatter for the content, nil if the content has no frontmatter.
+// The delimiters are found as for the content entries read by the generators.
+func parseFrontmatter(fileContent []byte) (*frontmatter, error) {
+	start, end, _, err := content.FrontmatterBounds(fileContent)
+	if errors.Is(err, content.ErrNoFrontmatter) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	newline := "\n"
+	if bytes.HasSuffix(fileContent[:start], []byte("\r\n")) {
+		newline = "\r\n"
+	}
+
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(fileContent[start:end], doc); err != nil {
+		return nil, fmt.Errorf("not valid frontmatter: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, errors.New("not valid frontmatter: expected key-value pairs")
+	}
+
+	return &frontmatter{doc: doc, mapping: mapping, newline: newline, head: fileContent[:start], tail: fileContent[end:]}, nil
+}
+
+// bytes returns the content with the updated frontmatter, the delimiters and the body are not changed.
+func (fm *frontmatter) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(fm.doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	yamlContent := buf.String()
+	if len(fm.mapping.Content) == 0 {
+		yamlContent = ""
+	}
+	if fm.newline != "\n" {
+		yamlContent = strings.ReplaceAll(yamlContent, "\n", fm.newline)
+	}
+
+	var sb bytes.Buffer
+	sb.Write(fm.head)
+	sb.WriteString(yamlContent)
+	sb.Write(fm.tail)
+	return sb.Bytes(), nil
+}
+
+// applyFrontmatterRule applies the rule to the mapping. It returns true if the mapping changed.
+func applyFrontmatterRule(mapping *yaml.Node, r *FrontmatterRule) (bool, error) {
+	keys := strings.Split(r.Key, ".")
+	parent, idx := lookupFrontmatterKey(mapping, keys)
+
+	switch r.Action {
+	case FrontmatterRename:
+		if idx == -1 {
+			return false, nil
+		}
+		newName := r.To[strings.LastIndex(r.To, ".")+1:]
+		if findMappingKey(parent, newName) != -1 {
+			return false, fmt.Errorf("cannot rename %s to %s: the key already exists", r.Key, newName)
+		}
+		parent.Content[idx].Value = newName
+		return true, nil
+
+	case FrontmatterMove:
+		if idx == -1 {
+			return false, nil
+		}
+		toKeys := strings.Split(r.To, ".")
+		if r.To == r.Key || strings.HasPrefix(r.To, r.Key+".") {
+			return false, fmt.Errorf("cannot move %s to %s: the destination is within the key", r.Key, r.To)
+		}
+		if _, toIdx := lookupFrontmatterKey(mapping, toKeys); toIdx != -1 {
+			return false, fmt.Errorf("cannot move %s to %s: the key already exists", r.Key, r.To)
+		}
+		// the destination is resolved first, the key is removed only when it can be moved.
+		toParent, err := ensureFrontmatterPath(mapping, toKeys[:len(toKeys)-1])
+		if err != nil {
+			return false, fmt.Errorf("cannot move %s to %s: %w", r.Key, r.To, err)
+		}
+		keyNode, valueNode := parent.Content[idx], parent.Content[idx+1]
+		parent.Content = append(parent.Content[:idx], parent.Content[idx+2:]...)
+		keyNode.Value = toKeys[len(toKeys)-1]
+		toParent.Content = append(toParent.Content, keyNode, valueNode)
+		return true, nil
+
+	case FrontmatterDefault:
+		if idx != -1 {
+			return false, nil
+		}
+		toParent, err := ensureFrontmatterPath(mapping, keys[:len(keys)-1])
+		if err != nil {
+			return false, fmt.Errorf("cannot set %s: %w", r.Key, err)
+		}
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(r.Value); err != nil {
+			return false, err
+		}
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keys[len(keys)-1]}
+		toParent.Content = append(toParent.Content, keyNode, valueNode)
+		return true, nil
+
+	case FrontmatterDelete:
+		if idx == -1 {
+			return false, nil
+		}
+		parent.Content = append(parent.Content[:idx], parent.Content[idx+2:]...)
+		return true, nil
+	}
+
+	return false, fmt.Errorf("unknown frontmatter action: %s", r.Action)
+}
+
+// lookupFrontmatterKey returns the mapping containing the key at the path and the index of the key
+// within its content. The index is -1 when the key does not exist.
+func lookupFrontmatterKey(mapping *yaml.Node, keys []string) (*yaml.Node, int) {
+	current := mapping
+	for i, key := range keys {
+		idx := findMappingKey(current, key)
+		if idx == -1 || i == len(keys)-1 {
+			return current, idx
+		}
+		current = current.Content[idx+1]
+		if current.Kind != yaml.MappingNode {
+			return nil, -1
+		}
+	}
+	return nil, -1
+}
+
+// ensureFrontmatterPath returns the mapping at the path, creating the missing ones.
+// Nothing is created when a key on the path is not a mapping.
+func ensureFrontmatterPath(mapping *yaml.Node, keys []string) (*yaml.Node, error) {
+	current := mapping
+	for _, key := range keys {
+		idx := findMappingKey(current, key)
+		if idx == -1 {
+			keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+			valueNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			current.Content = append(current.Content, keyNode, valueNode)
+			current = valueNode
+			continue
+		}
+		current = current.Content[idx+1]
+		if current.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s is not a mapping", key)
+		}
+	}
+	return current, nil
+}
+
+func findMappingKey(mapping *yaml.Node, key string) int {
+	if mapping == nil {
+		return -1
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,177 @@
+package migrations
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/internal/content"
+)
+
+const frontmatterTestBody = "\n## Heading\n\nSome *text* --- with: colons\n---\n"
+
+func TestFrontmatterRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		rules    []*FrontmatterRule
+		expected string
+		warnings int
+	}{
+		{
+			name:     "rename keeps the key position",
+			input:    "---\ntitle: Hello\nauthor: Jane\ndraft: false\n---\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterRename, Key: "author", To: "creator"}},
+			expected: "---\ntitle: Hello\ncreator: Jane\ndraft: false\n---\n",
+		},
+		{
+			name:     "rename a nested key",
+			input:    "---\ntitle: Hello\nmisc:\n  author: Jane\n  lang: en\n---\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterRename, Key: "misc.author", To: "misc.writer"}},
+			expected: "---\ntitle: Hello\nmisc:\n  writer: Jane\n  lang: en\n---\n",
+		},
+		{
+			name:     "rename to an existing key",
+			input:    "---\nauthor: Jane\ncreator: John\n---\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterRename, Key: "author", To: "creator"}},
+			expected: "---\nauthor: Jane\ncreator: John\n---\n",
+			warnings: 1,
+		},
+		{
+			name:     "move creates the missing parents at the end",
+			input:    "---\ntitle: Hello\nauthor: Jane\ndraft: false\n---\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterMove, Key: "author", To: "misc.author"}},
+			expected: "---\ntitle: Hello\ndraft: false\nmisc:\n  author: Jane\n---\n",
+		},
+		{
+			name:     "move into an existing mapping",
+			input:    "---\nmisc:\n  lang: en\nauthor: Jane\ntitle: Hello\n---\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterMove, Key: "author", To: "misc.author"}},
+			expected: "---\nmisc:\n  lang: en\n  author: Jane\ntitle: Hello\n---\n",
+		},
+		{
+			name:     "move to a scalar parent fails",
+			input:    "---\nauthor: Jane\nmisc: text\n---\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterMove, Key: "author", To: "misc.author"}},
+			expected: "---\nauthor: Jane\nmisc: text\n---\n",
+			warnings: 1,
+		},
+		{
+			name:  "failed move keeps the key when other rules change the file",
+			input: "---\nauthor: Jane\nmisc: text\nlayout: false\n---\n",
+			rules: []*FrontmatterRule{
+				{Action: FrontmatterMove, Key: "author", To: "misc.author"},
+				{Action: FrontmatterDelete, Key: "layout"},
+			},
+			expected: "---\nauthor: Jane\nmisc: text\n---\n",
+			warnings: 1,
+		},
+		{
+			name:     "move within the key fails",
+			input:    "---\nmisc:\n  lang: en\n---\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterMove, Key: "misc", To: "misc.old"}},
+			expected: "---\nmisc:\n  lang: en\n---\n",
+			warnings: 1,
+		},
+		{
+			name:  "default sets missing keys only",
+			input: "---\ntitle: Hello\ndraft: true\n---\n",
+			rules: []*FrontmatterRule{
+				{Action: FrontmatterDefault, Key: "draft", Value: false},
+				{Action: FrontmatterDefault, Key: "lang", Value: "en"},
+				{Action: FrontmatterDefault, Key: "seo.keywords", Value: []string{"a", "b"}},
+			},
+			expected: "---\ntitle: Hello\ndraft: true\nlang: en\nseo:\n  keywords:\n    - a\n    - b\n---\n",
+		},
+		{
+			name:     "default on an empty frontmatter",
+			input:    "---\n---\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterDefault, Key: "draft", Value: false}},
+			expected: "---\ndraft: false\n---\n",
+		},
+		{
+			name:     "delete",
+			input:    "---\ntitle: Hello\nlayout: false\ndraft: false\n---\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterDelete, Key: "layout"}},
+			expected: "---\ntitle: Hello\ndraft: false\n---\n",
+		},
+		{
+			name:     "missing keys are skipped",
+			input:    "---\ntitle: Hello\n---\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterRename, Key: "author", To: "creator"}, {Action: FrontmatterMove, Key: "a", To: "b"}, {Action: FrontmatterDelete, Key: "c"}},
+			expected: "---\ntitle: Hello\n---\n",
+		},
+		{
+			name:     "byte order mark and CRLF are kept",
+			input:    "\xef\xbb\xbf---\r\ntitle: Hello\r\nauthor: Jane\r\n---\r\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterRename, Key: "author", To: "creator"}},
+			expected: "\xef\xbb\xbf---\r\ntitle: Hello\r\ncreator: Jane\r\n---\r\n",
+		},
+		{
+			name:     "no frontmatter",
+			input:    "# Title\n",
+			rules:    []*FrontmatterRule{{Action: FrontmatterDefault, Key: "draft", Value: false}},
+			expected: "# Title\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			input := []byte(tc.input + frontmatterTestBody)
+			m := newTestFrontmatterMigration(afero.NewMemMapFs(), t, tc.rules)
+			got, err := m.runMigration(input, "content/posts/hello/index.svx")
+			is.NoErr(err)
+			is.Equal(string(got), tc.expected+frontmatterTestBody)
+			is.Equal(len(m.Services.warnings), tc.warnings)
+
+			if tc.expected == tc.input {
+				is.True(bytes.Equal(got, input)) // unchanged file
+			}
+			if _, _, inputBody, err := content.FrontmatterBounds(input); err == nil {
+				_, _, gotBody, err := content.FrontmatterBounds(got)
+				is.NoErr(err)
+				is.Equal(got[gotBody:], input[inputBody:]) // same body bytes
+			}
+		})
+	}
+}
+
+func TestFrontmatterMigrationFailedMove(t *testing.T) {
+	is := is.New(t)
+
+	memFs := afero.NewMemMapFs()
+	m := newTestFrontmatterMigration(memFs, t, []*FrontmatterRule{{Action: FrontmatterMove, Key: "author", To: "misc.author"}})
+	file := filepath.Join(m.Data.TargetPath, "posts", "hello", "index.svx")
+	input := []byte("---\nauthor: Jane\nmisc: text\n---\n" + frontmatterTestBody)
+	is.NoErr(afero.WriteFile(memFs, file, input, 0644))
+
+	is.NoErr(m.Migrate())
+	got, err := afero.ReadFile(memFs, file)
+	is.NoErr(err)
+	is.Equal(got, input)
+	is.Equal(len(m.Services.warnings), 1)
+	is.Equal(len(m.Services.changes), 0)
+}
+
+func TestParseFrontmatterErrors(t *testing.T) {
+	is := is.New(t)
+
+	_, err := parseFrontmatter([]byte("---\ntitle: Hello\n"))
+	is.True(err != nil) // closing delimiter not found
+	_, err = parseFrontmatter([]byte("---\n- a\n- b\n---\n"))
+	is.True(err != nil) // not a mapping
+	_, err = parseFrontmatter([]byte("---\ntitle: [\n---\n"))
+	is.True(err != nil) // not valid YAML
+}
+
+func newTestFrontmatterMigration(memFs afero.Fs, t *testing.T, rules []*FrontmatterRule) *ApplyFrontmatterMigration {
+	pathMaker := newTestPathMaker(t)
+	services := newTestServices(memFs, pathMaker)
+	data := &MigrationData{TargetPath: filepath.Join(pathMaker.GetRootFolder(), "content")}
+	m := NewFrontmatterMigration(NewMigrationManager(), services, data, &FrontmatterMigration{Name: "test", Rules: rules})
+	return m.(*ApplyFrontmatterMigration)
+}
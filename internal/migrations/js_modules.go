@@ -0,0 +1,48 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package migrations
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/sveltinio/sveltin/internal/jsmod"
+)
+
+// jsModuleEditFunc edits the structure of a JS/TS module.
+type jsModuleEditFunc = func(*jsmod.Module) error
+
+// editJSModule applies the edit to the JS/TS config file content. When the file is too customised
+// to be edited, it falls back to the line based migration and reports a warning. If the fallback
+// does not change anything, manualFix tells what must be done by hand.
+func editJSModule(m IMigration, content []byte, file string, edit jsModuleEditFunc, fallback func([]byte) ([]byte, error), manualFix string) ([]byte, error) {
+	module, err := jsmod.Parse(content)
+	if err == nil {
+		if err = edit(module); err == nil {
+			if !bytes.Equal(module.Bytes(), content) {
+				recordRule(m, "js-module-edit")
+			}
+			return module.Bytes(), nil
+		}
+	}
+	if !errors.Is(err, jsmod.ErrUnsupported) && !errors.Is(err, jsmod.ErrNotFound) {
+		return nil, err
+	}
+
+	output, fallbackErr := fallback(content)
+	if fallbackErr != nil {
+		return nil, fallbackErr
+	}
+	if bytes.Equal(output, content) {
+		addWarning(m, file, 0, fmt.Sprintf("%s (%s)", manualFix, err))
+	} else {
+		addWarning(m, file, 0, fmt.Sprintf("file too customised, line based migration applied: check the changes (%s)", err))
+	}
+	return output, nil
+}
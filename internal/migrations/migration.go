@@ -11,6 +11,7 @@ package migrations
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -39,6 +40,11 @@ type MigrationServices struct {
 	fsManager *fsm.SveltinFSManager
 	pathMaker *pathmaker.SveltinPathMaker
 	logger    *yinlog.Logger
+	dryRun    bool
+	changes   []*FileChange
+	warnings  []*Warning
+	snapshot  *Snapshot
+	executed  []*MigrationReport
 }
 
 // NewMigrationServices creates an instance of MigrationService struct.
@@ -51,8 +57,55 @@ func NewMigrationServices(fs afero.Fs, fsm *fsm.SveltinFSManager, pathmaker *pat
 	}
 }
 
+// NewDryRunMigrationServices creates an instance of MigrationService struct to preview migrations.
+// Files are read from fs but all the changes are kept in memory.
+func NewDryRunMigrationServices(fs afero.Fs, fsm *fsm.SveltinFSManager, pathmaker *pathmaker.SveltinPathMaker, logger *yinlog.Logger) *MigrationServices {
+	services := NewMigrationServices(afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(fs), afero.NewMemMapFs()), fsm, pathmaker, logger)
+	services.dryRun = true
+	return services
+}
+
+// IsDryRun returns true if migrations are previewed only.
+func (s *MigrationServices) IsDryRun() bool {
+	return s.dryRun
+}
+
+// GetFS returns the afero.Fs implementation used by the migrations.
+func (s *MigrationServices) GetFS() afero.Fs {
+	return s.fs
+}
+
+// SetSnapshot sets the snapshot used to backup files before they are changed by the migrations.
+func (s *MigrationServices) SetSnapshot(snapshot *Snapshot) {
+	s.snapshot = snapshot
+}
+
+// GetChanges returns the list of changes made by the migrations, in execution order.
+func (s *MigrationServices) GetChanges() []*FileChange {
+	return s.changes
+}
+
+// GetWarnings returns the list of warnings raised by the migrations, in execution order.
+func (s *MigrationServices) GetWarnings() []*Warning {
+	return s.warnings
+}
+
+// Run executes the migration and keeps track of it for the report.
+func (s *MigrationServices) Run(m IMigration) error {
+	s.executed = append(s.executed, &MigrationReport{
+		Name:     m.getData().GetName(),
+		Target:   m.getData().TargetPath,
+		Files:    []string{},
+		Rules:    []string{},
+		Warnings: []string{},
+	})
+	return m.Migrate()
+}
+
 // MigrationData is the struct with data used by migrations.
 type MigrationData struct {
+	ID                Migration
+	Name              string
 	TargetPath        string
 	CliVersion        string
 	ProjectCliVersion string
@@ -66,6 +119,14 @@ type migrationRule struct {
 	replacerFunc    func(string) string
 }
 
+// GetName returns the migration name. It defaults to the name of the migration id.
+func (d *MigrationData) GetName() string {
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.ID.String()
+}
+
 //=============================================================================
 
 func mustMigrate(content []byte, gatekeeper string) bool {
@@ -85,11 +146,12 @@ func patternsMatched(content []byte, patterns []string, matcher matcherFunc) boo
 	return false
 }
 
-func applyMigrationRules(rules []*migrationRule) (string, bool) {
+func applyMigrationRules(m IMigration, rules []*migrationRule) (string, bool) {
 	for _, r := range rules {
 		expression := regexp.MustCompile(r.trigger)
 
 		if expression.MatchString(r.value) {
+			recordRule(m, r.trigger)
 			if r.replaceFullLine {
 				return r.replacerFunc(r.value), true
 			}
@@ -115,30 +177,63 @@ func retrieveFileContent(fs afero.Fs, pathToFile string) ([]byte, error) {
 	return content, nil
 }
 
-func overwriteFile(m IMigration, content []byte) error {
-	err := m.getServices().fs.Remove(m.getData().TargetPath)
-	if err != nil {
-		return err
+// writeFile saves the migrated content to the file and keeps track of the change.
+// Nothing is written when the content is unchanged.
+func writeFile(m IMigration, file string, content []byte) error {
+	return m.getServices().writeFile(m.getData().GetName(), file, content)
+}
+
+func (s *MigrationServices) writeFile(migrationName, file string, content []byte) error {
+	change := &FileChange{
+		Migration: migrationName,
+		Path:      file,
+		After:     content,
 	}
 
-	if err = afero.WriteFile(m.getServices().fs, m.getData().TargetPath, []byte(content), 0644); err != nil {
-		return err
+	exists, _ := afero.Exists(s.fs, file)
+	if exists {
+		current, err := afero.ReadFile(s.fs, file)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(current, content) {
+			return nil
+		}
+		change.Before = current
+	} else {
+		change.IsNew = true
 	}
-	return nil
-}
 
-func appendToFile(fs afero.Fs, filename string, contentToAppend []string, logger *yinlog.Logger) {
-	f, err := fs.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0644)
-	if err != nil {
-		logger.Fatalf("failed opening file: %s", err)
+	if s.snapshot != nil {
+		if err := s.snapshot.add(file, change.IsNew); err != nil {
+			return err
+		}
 	}
-	defer f.Close()
 
-	for _, content := range contentToAppend {
-		if _, err = f.WriteString(content); err != nil {
-			logger.Fatalf("failed writing to file: %s", err)
+	if change.IsNew {
+		if err := s.fs.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+			return err
 		}
 	}
+
+	if err := afero.WriteFile(s.fs, file, content, 0644); err != nil {
+		return err
+	}
+	s.changes = append(s.changes, change)
+	return nil
+}
+
+// addWarning keeps track of something the migration could not change safely and logs it.
+func addWarning(m IMigration, file string, line int, message string) {
+	s := m.getServices()
+	w := &Warning{
+		Migration: m.getData().GetName(),
+		Line:      line,
+		Message:   message,
+	}
+	w.Path = relativeTo(s.pathMaker.GetRootFolder(), file)
+	s.warnings = append(s.warnings, w)
+	s.logger.Warning(w.String())
 }
 
 func getTextInBetween(text string, start string, end string) string {
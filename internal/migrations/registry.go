@@ -0,0 +1,218 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package migrations
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/common"
+	"github.com/sveltinio/sveltin/utils"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// appliedMigrationsKey is the sveltin.json key listing the applied migrations.
+const appliedMigrationsKey = "sveltin.migrations"
+
+// GetMigrationsToApply returns the migrations to run when upgrading a project
+// from projectVersion to cliVersion, sorted by version. Migrations already
+// applied to the project are skipped.
+func GetMigrationsToApply(projectVersion, cliVersion string, applied []string) ([]Migration, error) {
+	projectSemVer, err := utils.ParseSemVer(projectVersion)
+	if err != nil {
+		return nil, err
+	}
+	cliSemVer, err := utils.ParseSemVer(cliVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []Migration{}
+	for id, vr := range migrationVersionMap {
+		if isApplied(applied, id) {
+			continue
+		}
+		ok, err := vr.includes(projectSemVer, cliSemVer)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		if ok {
+			selected = append(selected, id)
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		vi, _ := utils.ParseSemVer(migrationVersionMap[selected[i]].to)
+		vj, _ := utils.ParseSemVer(migrationVersionMap[selected[j]].to)
+		if c := vi.Compare(vj); c != 0 {
+			return c < 0
+		}
+		return selected[i] < selected[j]
+	})
+
+	return selected, nil
+}
+
+// GetAppliedMigrations returns the ids of the migrations already applied
+// as listed in the project settings file.
+func GetAppliedMigrations(fs afero.Fs, pathToFile string) ([]string, error) {
+	content, err := afero.ReadFile(fs, pathToFile)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []string{}
+	for _, v := range gjson.GetBytes(content, appliedMigrationsKey).Array() {
+		name := v.String()
+		// replace the legacy names so that they are updated when recording the applied migrations.
+		if id, ok := legacyMigrationNameMap[name]; ok {
+			name = id.String()
+		}
+		if !common.Contains(applied, name) {
+			applied = append(applied, name)
+		}
+	}
+	return applied, nil
+}
+
+// isApplied returns true if the migration, or one of its legacy names, is listed as applied.
+func isApplied(applied []string, id Migration) bool {
+	for _, name := range applied {
+		if name == id.String() {
+			return true
+		}
+		if legacyID, ok := legacyMigrationNameMap[name]; ok && legacyID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordAppliedMigrations adds the ids of the applied migrations to the project settings file.
+// Only the key is set, the rest of the file is kept as it is.
+func RecordAppliedMigrations(services *MigrationServices, pathToFile string, migrations []Migration) error {
+	if len(migrations) == 0 {
+		return nil
+	}
+
+	applied, err := GetAppliedMigrations(services.fs, pathToFile)
+	if err != nil {
+		return err
+	}
+	for _, id := range migrations {
+		if !common.Contains(applied, id.String()) {
+			applied = append(applied, id.String())
+		}
+	}
+
+	content, err := afero.ReadFile(services.fs, pathToFile)
+	if err != nil {
+		return err
+	}
+	content, err = sjson.SetBytes(content, appliedMigrationsKey, applied)
+	if err != nil {
+		return err
+	}
+
+	return services.writeFile(ProjectSettings.String(), pathToFile, content)
+}
+
+//=============================================================================
+
+// includes returns true if the migration applies when upgrading from
+// projectVersion to cliVersion.
+func (vr versionRange) includes(projectVersion, cliVersion *utils.SemVer) (bool, error) {
+	to, err := utils.ParseSemVer(vr.to)
+	if err != nil {
+		return false, err
+	}
+	if projectVersion.Compare(to) >= 0 || to.Compare(cliVersion) > 0 {
+		return false, nil
+	}
+
+	if vr.from != "" {
+		from, err := utils.ParseSemVer(vr.from)
+		if err != nil {
+			return false, err
+		}
+		if projectVersion.Compare(from) < 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+//=============================================================================
+
+// MigrationStatus is the type to identify the status of a migration for a project.
+type MigrationStatus int
+
+// Migration statuses.
+const (
+	Applied MigrationStatus = iota
+	Pending
+	NotApplicable
+)
+
+var migrationStatusNameMap = map[MigrationStatus]string{
+	Applied:       "applied",
+	Pending:       "pending",
+	NotApplicable: "not applicable",
+}
+
+// String returns the status name.
+func (s MigrationStatus) String() string {
+	return migrationStatusNameMap[s]
+}
+
+// GetAllMigrations returns all the registered migrations sorted by id.
+func GetAllMigrations() []Migration {
+	ids := []Migration{}
+	for id := range migrationMap {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// GetMigrationsStatus returns the status of each registered migration for a project
+// created with projectVersion when upgrading it to cliVersion.
+func GetMigrationsStatus(projectVersion, cliVersion string, applied []string) (map[Migration]MigrationStatus, error) {
+	pending, err := GetMigrationsToApply(projectVersion, cliVersion, applied)
+	if err != nil {
+		return nil, err
+	}
+
+	statusMap := map[Migration]MigrationStatus{}
+	for _, id := range GetAllMigrations() {
+		switch {
+		case isApplied(applied, id):
+			statusMap[id] = Applied
+		default:
+			statusMap[id] = NotApplicable
+		}
+	}
+	for _, id := range pending {
+		statusMap[id] = Pending
+	}
+
+	// the project settings file is up to date when it reports the cli version.
+	c, err := utils.CompareSemVer(projectVersion, cliVersion)
+	if err != nil {
+		return nil, err
+	}
+	if projectVersion != "" && c == 0 {
+		statusMap[ProjectSettings] = Applied
+	} else {
+		statusMap[ProjectSettings] = Pending
+	}
+
+	return statusMap, nil
+}
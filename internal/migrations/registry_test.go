@@ -0,0 +1,135 @@
+package migrations
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/sveltinio/sveltin/utils"
+)
+
+func TestVersionRangeIncludes(t *testing.T) {
+	tests := []struct {
+		name     string
+		vr       versionRange
+		project  string
+		cli      string
+		expected bool
+	}{
+		{name: "project older than to", vr: versionRange{to: "0.11.0"}, project: "0.10.1", cli: "0.11.0", expected: true},
+		{name: "project at to", vr: versionRange{to: "0.11.0"}, project: "0.11.0", cli: "0.12.0"},
+		{name: "cli older than to", vr: versionRange{to: "0.11.0"}, project: "0.9.0", cli: "0.10.1"},
+		{name: "project within from and to", vr: versionRange{from: "0.9.0", to: "0.11.0"}, project: "0.9.0", cli: "0.11.0", expected: true},
+		{name: "project older than from", vr: versionRange{from: "0.9.0", to: "0.11.0"}, project: "0.8.12", cli: "0.11.0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			project, err := utils.ParseSemVer(tc.project)
+			is.NoErr(err)
+			cli, err := utils.ParseSemVer(tc.cli)
+			is.NoErr(err)
+			got, err := tc.vr.includes(project, cli)
+			is.NoErr(err)
+			is.Equal(got, tc.expected)
+		})
+	}
+
+	is := is.New(t)
+	project, _ := utils.ParseSemVer("0.9.0")
+	_, err := versionRange{to: "next"}.includes(project, project)
+	is.True(err != nil) // not valid version
+}
+
+func TestGetMigrationsToApply(t *testing.T) {
+	is := is.New(t)
+
+	pending, err := GetMigrationsToApply("0.10.1", "0.11.0", nil)
+	is.NoErr(err)
+	is.Equal(len(pending), len(migrationVersionMap))
+	for i := 1; i < len(pending); i++ {
+		is.True(pending[i-1] < pending[i]) // same version, sorted by id
+	}
+
+	pending, err = GetMigrationsToApply("0.10.1", "0.11.0", []string{DotEnv.String(), "vire-config-ts"})
+	is.NoErr(err)
+	is.Equal(len(pending), len(migrationVersionMap)-2)
+	for _, id := range pending {
+		is.True(id != DotEnv && id != ViteConfig) // already applied
+	}
+
+	pending, err = GetMigrationsToApply("0.11.0", "0.11.0", nil)
+	is.NoErr(err)
+	is.Equal(len(pending), 0)
+
+	_, err = GetMigrationsToApply("0.10.1", "latest", nil)
+	is.True(err != nil)
+}
+
+func TestIsApplied(t *testing.T) {
+	is := is.New(t)
+
+	is.True(isApplied([]string{"dotenv"}, DotEnv))
+	is.True(isApplied([]string{"vire-config-ts"}, ViteConfig)) // legacy name
+	is.True(!isApplied([]string{"vire-config-ts"}, TSConfig))
+	is.True(!isApplied(nil, DotEnv))
+}
+
+func TestRecordAppliedMigrations(t *testing.T) {
+	is := is.New(t)
+
+	memFs := afero.NewMemMapFs()
+	pathMaker := newTestPathMaker(t)
+	services := newTestServices(memFs, pathMaker)
+	pathToFile := filepath.Join(pathMaker.GetRootFolder(), "sveltin.json")
+	is.NoErr(afero.WriteFile(memFs, pathToFile, []byte("{\n  \"name\": \"site\",\n  \"sveltin\": {\"version\": \"0.10.1\", \"migrations\": [\"vire-config-ts\", \"dotenv\"]}\n}\n"), 0644))
+
+	applied, err := GetAppliedMigrations(memFs, pathToFile)
+	is.NoErr(err)
+	is.Equal(applied, []string{"vite-config-ts", "dotenv"}) // legacy names replaced
+
+	is.NoErr(RecordAppliedMigrations(services, pathToFile, []Migration{DotEnv, TSConfig}))
+	content, err := afero.ReadFile(memFs, pathToFile)
+	is.NoErr(err)
+	// only the key is changed.
+	is.Equal(string(content), "{\n  \"name\": \"site\",\n  \"sveltin\": {\"version\": \"0.10.1\", \"migrations\": [\"vite-config-ts\",\"dotenv\",\"ts-config-ts\"]}\n}\n")
+
+	is.NoErr(RecordAppliedMigrations(services, pathToFile, nil))
+	is.Equal(len(services.changes), 1)
+}
+
+func TestGetMigrationsStatus(t *testing.T) {
+	is := is.New(t)
+
+	statusMap, err := GetMigrationsStatus("0.10.1", "0.11.0", []string{"dotenv", "vire-config-ts"})
+	is.NoErr(err)
+	is.Equal(len(statusMap), len(GetAllMigrations()))
+	for id, status := range statusMap {
+		switch id {
+		case DotEnv, ViteConfig:
+			is.Equal(status, Applied)
+		default:
+			is.Equal(status, Pending)
+		}
+	}
+
+	statusMap, err = GetMigrationsStatus("0.11.0", "0.11.0", nil)
+	is.NoErr(err)
+	for id, status := range statusMap {
+		if id == ProjectSettings {
+			is.Equal(status, Applied) // sveltin.json reports the cli version
+			continue
+		}
+		is.Equal(status, NotApplicable)
+	}
+
+	statusMap, err = GetMigrationsStatus("", "0.11.0", nil)
+	is.NoErr(err)
+	is.Equal(statusMap[ProjectSettings], Pending)
+	is.Equal(statusMap[DotEnv], Pending)
+	is.Equal(Pending.String(), "pending")
+	is.Equal(NotApplicable.String(), "not applicable")
+}
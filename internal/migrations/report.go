@@ -0,0 +1,189 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package migrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/tidwall/gjson"
+)
+
+// Report file names.
+const (
+	ReportJSONFile     = "migration-report.json"
+	ReportMarkdownFile = "migration-report.md"
+)
+
+// Report is the struct representing the outcome of a sveltin migrate run.
+type Report struct {
+	CreatedAt   string             `json:"createdAt"`
+	DryRun      bool               `json:"dryRun"`
+	FromVersion string             `json:"fromVersion"`
+	Sveltin     string             `json:"sveltin"`
+	Packages    map[string]string  `json:"packages"`
+	Migrations  []*MigrationReport `json:"migrations"`
+}
+
+// MigrationReport is the struct representing the outcome of a single migration.
+type MigrationReport struct {
+	Name     string   `json:"name"`
+	Target   string   `json:"target"`
+	Changed  bool     `json:"changed"`
+	Files    []string `json:"files"`
+	Rules    []string `json:"rules"`
+	Warnings []string `json:"warnings"`
+}
+
+// recordRule keeps track of a rule triggered by the migration.
+func recordRule(m IMigration, rule string) {
+	s := m.getServices()
+	name := m.getData().GetName()
+	for i := len(s.executed) - 1; i >= 0; i-- {
+		if s.executed[i].Name != name {
+			continue
+		}
+		for _, r := range s.executed[i].Rules {
+			if r == rule {
+				return
+			}
+		}
+		s.executed[i].Rules = append(s.executed[i].Rules, rule)
+		return
+	}
+}
+
+// BuildReport returns the report for the migrations executed by Run.
+// fromVersion is the sveltin version the project has been migrated from.
+func (s *MigrationServices) BuildReport(fromVersion, cliVersion string) *Report {
+	root := s.pathMaker.GetRootFolder()
+	report := &Report{
+		CreatedAt:   time.Now().Format(time.RFC3339),
+		DryRun:      s.dryRun,
+		FromVersion: fromVersion,
+		Sveltin:     cliVersion,
+		Packages:    s.getSveltinioPackages(filepath.Join(root, "package.json")),
+		Migrations:  s.executed,
+	}
+
+	_, changesByMigration := GroupChangesByMigration(s.changes)
+	for _, mr := range report.Migrations {
+		mr.Target = relativeTo(root, mr.Target)
+		for _, c := range changesByMigration[mr.Name] {
+			mr.Changed = true
+			mr.Files = appendIfMissing(mr.Files, relativeTo(root, c.Path))
+		}
+		for _, w := range s.warnings {
+			if w.Migration == mr.Name {
+				mr.Warnings = appendIfMissing(mr.Warnings, w.String())
+			}
+		}
+	}
+	return report
+}
+
+// getSveltinioPackages returns the versions of the @sveltinio/* packages in package.json.
+func (s *MigrationServices) getSveltinioPackages(pathToFile string) map[string]string {
+	packages := map[string]string{}
+	content, err := afero.ReadFile(s.fs, pathToFile)
+	if err != nil {
+		return packages
+	}
+	for _, key := range []string{"dependencies", "devDependencies"} {
+		gjson.GetBytes(content, key).ForEach(func(name, version gjson.Result) bool {
+			if strings.HasPrefix(name.String(), "@sveltinio/") {
+				packages[name.String()] = version.String()
+			}
+			return true
+		})
+	}
+	return packages
+}
+
+// WriteReport saves the report as JSON and markdown files within the folder.
+func WriteReport(fs afero.Fs, folder string, report *Report) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fs, filepath.Join(folder, ReportJSONFile), content, 0644); err != nil {
+		return err
+	}
+	return afero.WriteFile(fs, filepath.Join(folder, ReportMarkdownFile), []byte(report.Markdown()), 0644)
+}
+
+// Markdown returns the report summary in markdown.
+func (r *Report) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# Migration report\n\n")
+	fromVersion := r.FromVersion
+	if fromVersion == "" {
+		fromVersion = "unknown"
+	}
+	sb.WriteString(fmt.Sprintf("Migrated from sveltin **%s** to **%s** on %s.\n\n", fromVersion, r.Sveltin, r.CreatedAt))
+
+	sb.WriteString("## Migrations\n\n")
+	sb.WriteString("| Migration | Target | Changed | Files | Rules | Warnings |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	for _, m := range r.Migrations {
+		changed := "no"
+		if m.Changed {
+			changed = "yes"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | `%s` | %s | %d | %d | %d |\n", m.Name, m.Target, changed, len(m.Files), len(m.Rules), len(m.Warnings)))
+	}
+
+	warnings := []string{}
+	for _, m := range r.Migrations {
+		for _, w := range m.Warnings {
+			warnings = append(warnings, fmt.Sprintf("- **%s**: %s\n", m.Name, w))
+		}
+	}
+	if len(warnings) > 0 {
+		sb.WriteString("\n## Warnings\n\n")
+		for _, w := range warnings {
+			sb.WriteString(w)
+		}
+	}
+
+	if len(r.Packages) > 0 {
+		names := make([]string, 0, len(r.Packages))
+		for name := range r.Packages {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		sb.WriteString("\n## Packages\n\n")
+		sb.WriteString("| Package | Version |\n")
+		sb.WriteString("| --- | --- |\n")
+		for _, name := range names {
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", name, r.Packages[name]))
+		}
+	}
+	return sb.String()
+}
+
+func relativeTo(root, file string) string {
+	if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return file
+}
+
+func appendIfMissing(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestReport(t *testing.T) {
+	is := is.New(t)
+
+	memFs := afero.NewMemMapFs()
+	pathMaker := newTestPathMaker(t)
+	services := newTestServices(memFs, pathMaker)
+	root := pathMaker.GetRootFolder()
+	is.NoErr(afero.WriteFile(memFs, filepath.Join(root, "package.json"), []byte(`{"dependencies": {"@sveltinio/seo": "^0.3.0", "svelte": "^3.55.0"}, "devDependencies": {"@sveltinio/widgets": "^0.5.0"}}`), 0644))
+	is.NoErr(afero.WriteFile(memFs, filepath.Join(root, "src", "a.ts"), []byte("const old = 1;\n"), 0644))
+	is.NoErr(afero.WriteFile(memFs, filepath.Join(root, "content", "posts", "a", "index.svx"), []byte("---\nauthor: Jane\nmisc: text\n---\n"), 0644))
+
+	user := NewUserMigration(NewMigrationManager(), services, &MigrationData{TargetPath: root}, &UserMigration{Name: "rename-old", Target: "src/*.ts", Rules: []*UserMigrationRule{{Trigger: `old`, Replace: `new`}}})
+	is.NoErr(services.Run(user))
+	fm := NewFrontmatterMigration(NewMigrationManager(), services, &MigrationData{TargetPath: filepath.Join(root, "content")}, &FrontmatterMigration{Name: "move-author", Rules: []*FrontmatterRule{{Action: FrontmatterMove, Key: "author", To: "misc.author"}}})
+	is.NoErr(services.Run(fm))
+
+	report := services.BuildReport("0.10.1", "0.11.0")
+	is.Equal(report.FromVersion, "0.10.1")
+	is.Equal(report.Sveltin, "0.11.0")
+	is.True(!report.DryRun)
+	is.Equal(report.Packages, map[string]string{"@sveltinio/seo": "^0.3.0", "@sveltinio/widgets": "^0.5.0"})
+	is.Equal(len(report.Migrations), 2)
+	is.Equal(*report.Migrations[0], MigrationReport{Name: "rename-old", Target: ".", Changed: true, Files: []string{filepath.Join("src", "a.ts")}, Rules: []string{"old"}, Warnings: []string{}})
+	is.Equal(report.Migrations[1].Name, "move-author")
+	is.Equal(report.Migrations[1].Target, "content")
+	is.True(!report.Migrations[1].Changed)
+	is.Equal(len(report.Migrations[1].Warnings), 1)
+	is.True(strings.HasPrefix(report.Migrations[1].Warnings[0], filepath.Join("content", "posts", "a", "index.svx")+": "))
+
+	is.NoErr(WriteReport(memFs, root, report))
+
+	content, err := afero.ReadFile(memFs, filepath.Join(root, ReportJSONFile))
+	is.NoErr(err)
+	var saved map[string]interface{}
+	is.NoErr(json.Unmarshal(content, &saved))
+	is.Equal(saved["fromVersion"], "0.10.1")
+	is.Equal(saved["sveltin"], "0.11.0")
+	is.Equal(saved["dryRun"], false)
+	migrations := saved["migrations"].([]interface{})
+	is.Equal(len(migrations), 2)
+	first := migrations[0].(map[string]interface{})
+	is.Equal(first["name"], "rename-old")
+	is.Equal(first["changed"], true)
+	is.Equal(first["files"], []interface{}{filepath.Join("src", "a.ts")})
+
+	content, err = afero.ReadFile(memFs, filepath.Join(root, ReportMarkdownFile))
+	is.NoErr(err)
+	md := string(content)
+	is.True(strings.HasPrefix(md, "# Migration report\n\nMigrated from sveltin **0.10.1** to **0.11.0** on "+report.CreatedAt+".\n"))
+	is.True(strings.Contains(md, "| Migration | Target | Changed | Files | Rules | Warnings |\n| --- | --- | --- | --- | --- | --- |\n| rename-old | `.` | yes | 1 | 1 | 0 |\n| move-author | `content` | no | 0 | 0 | 1 |\n"))
+	is.True(strings.Contains(md, "\n## Warnings\n\n- **move-author**: "+report.Migrations[1].Warnings[0]+"\n"))
+	is.True(strings.HasSuffix(md, "\n## Packages\n\n| Package | Version |\n| --- | --- |\n| @sveltinio/seo | ^0.3.0 |\n| @sveltinio/widgets | ^0.5.0 |\n"))
+}
+
+func TestReportMarkdownUnknownVersion(t *testing.T) {
+	is := is.New(t)
+
+	md := (&Report{Sveltin: "0.11.0", CreatedAt: "now", Migrations: []*MigrationReport{}}).Markdown()
+	is.Equal(md, "# Migration report\n\nMigrated from sveltin **unknown** to **0.11.0** on now.\n\n## Migrations\n\n| Migration | Target | Changed | Files | Rules | Warnings |\n| --- | --- | --- | --- | --- | --- |\n")
+}
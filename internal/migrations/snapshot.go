@@ -0,0 +1,164 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package migrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	snapshotManifestFile = "snapshot.json"
+	snapshotFilesFolder  = "files"
+	snapshotTimeLayout   = "2006-01-02_15-04-05"
+)
+
+// Snapshot is the struct representing a backup of the files touched by the migrations.
+type Snapshot struct {
+	fs         afero.Fs
+	rootFolder string
+	folder     string
+	CreatedAt  string          `json:"createdAt"`
+	Files      []*SnapshotFile `json:"files"`
+}
+
+// SnapshotFile is the struct representing a file saved in a snapshot.
+// Path is relative to the project root folder.
+type SnapshotFile struct {
+	Path  string `json:"path"`
+	IsNew bool   `json:"isNew"`
+}
+
+// NewSnapshot returns a pointer to a Snapshot saved in a timestamped folder within the backups folder.
+func NewSnapshot(fs afero.Fs, rootFolder, backupsFolder string) *Snapshot {
+	createdAt := time.Now().Format(snapshotTimeLayout)
+	return &Snapshot{
+		fs:         fs,
+		rootFolder: rootFolder,
+		folder:     filepath.Join(backupsFolder, createdAt),
+		CreatedAt:  createdAt,
+		Files:      []*SnapshotFile{},
+	}
+}
+
+// LoadLatestSnapshot returns the most recent snapshot saved within the backups folder.
+func LoadLatestSnapshot(fs afero.Fs, rootFolder, backupsFolder string) (*Snapshot, error) {
+	entries, err := afero.ReadDir(fs, backupsFolder)
+	if err != nil {
+		return nil, fmt.Errorf("no migration snapshots found in %s", backupsFolder)
+	}
+
+	folders := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			folders = append(folders, entry.Name())
+		}
+	}
+	if len(folders) == 0 {
+		return nil, fmt.Errorf("no migration snapshots found in %s", backupsFolder)
+	}
+	// timestamped folder names sort chronologically.
+	sort.Strings(folders)
+	latest := folders[len(folders)-1]
+
+	content, err := afero.ReadFile(fs, filepath.Join(backupsFolder, latest, snapshotManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		fs:         fs,
+		rootFolder: rootFolder,
+		folder:     filepath.Join(backupsFolder, latest),
+	}
+	if err := json.Unmarshal(content, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetFolder returns the path to the folder where the snapshot is saved.
+func (s *Snapshot) GetFolder() string {
+	return s.folder
+}
+
+// IsEmpty returns true if no files have been saved in the snapshot.
+func (s *Snapshot) IsEmpty() bool {
+	return len(s.Files) == 0
+}
+
+// Restore copies back the saved files and removes the ones created by the migrations.
+func (s *Snapshot) Restore() error {
+	for _, f := range s.Files {
+		target := filepath.Join(s.rootFolder, f.Path)
+		if f.IsNew {
+			if err := s.fs.Remove(target); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+
+		content, err := afero.ReadFile(s.fs, filepath.Join(s.folder, snapshotFilesFolder, f.Path))
+		if err != nil {
+			return err
+		}
+		if err := afero.WriteFile(s.fs, target, content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add saves a copy of the file unless it is already in the snapshot.
+func (s *Snapshot) add(file string, isNew bool) error {
+	relPath, err := filepath.Rel(s.rootFolder, file)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range s.Files {
+		if f.Path == relPath {
+			return nil
+		}
+	}
+
+	if !isNew {
+		content, err := afero.ReadFile(s.fs, file)
+		if err != nil {
+			return err
+		}
+		saveAs := filepath.Join(s.folder, snapshotFilesFolder, relPath)
+		if err := s.fs.MkdirAll(filepath.Dir(saveAs), os.ModePerm); err != nil {
+			return err
+		}
+		if err := afero.WriteFile(s.fs, saveAs, content, 0644); err != nil {
+			return err
+		}
+	}
+
+	s.Files = append(s.Files, &SnapshotFile{Path: relPath, IsNew: isNew})
+	return s.save()
+}
+
+// save writes the snapshot manifest file.
+func (s *Snapshot) save() error {
+	if err := s.fs.MkdirAll(s.folder, os.ModePerm); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(s.fs, filepath.Join(s.folder, snapshotManifestFile), content, 0644)
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestSnapshotAddAndRestore(t *testing.T) {
+	is := is.New(t)
+
+	memFs := afero.NewMemMapFs()
+	root := filepath.Join("/", "project")
+	backups := filepath.Join(root, "backups", "migrations")
+	changed := filepath.Join(root, "src", "app.html")
+	created := filepath.Join(root, "src", "lib", "new.ts")
+	is.NoErr(afero.WriteFile(memFs, changed, []byte("original"), 0644))
+
+	snapshot := NewSnapshot(memFs, root, backups)
+	is.True(snapshot.IsEmpty())
+	is.NoErr(snapshot.add(changed, false))
+	is.NoErr(snapshot.add(created, true))
+	is.NoErr(snapshot.add(changed, false)) // already saved
+	is.Equal(len(snapshot.Files), 2)
+	is.Equal(*snapshot.Files[0], SnapshotFile{Path: filepath.Join("src", "app.html")})
+	is.Equal(*snapshot.Files[1], SnapshotFile{Path: filepath.Join("src", "lib", "new.ts"), IsNew: true})
+
+	// the saved copy is the content before the first change.
+	is.NoErr(afero.WriteFile(memFs, changed, []byte("migrated"), 0644))
+	is.NoErr(snapshot.add(changed, false))
+	is.NoErr(afero.WriteFile(memFs, created, []byte("created"), 0644))
+	saved, err := afero.ReadFile(memFs, filepath.Join(snapshot.GetFolder(), snapshotFilesFolder, "src", "app.html"))
+	is.NoErr(err)
+	is.Equal(string(saved), "original")
+
+	loaded, err := LoadLatestSnapshot(memFs, root, backups)
+	is.NoErr(err)
+	is.Equal(loaded.CreatedAt, snapshot.CreatedAt)
+	is.Equal(len(loaded.Files), 2)
+
+	is.NoErr(loaded.Restore())
+	content, err := afero.ReadFile(memFs, changed)
+	is.NoErr(err)
+	is.Equal(string(content), "original")
+	exists, err := afero.Exists(memFs, created)
+	is.NoErr(err)
+	is.True(!exists) // created by the migration
+
+	is.NoErr(loaded.Restore()) // files already removed are skipped
+}
+
+func TestLoadLatestSnapshot(t *testing.T) {
+	is := is.New(t)
+
+	memFs := afero.NewMemMapFs()
+	root := filepath.Join("/", "project")
+	backups := filepath.Join(root, "backups", "migrations")
+
+	_, err := LoadLatestSnapshot(memFs, root, backups)
+	is.True(err != nil) // no backups folder
+	is.NoErr(memFs.MkdirAll(backups, 0755))
+	is.NoErr(afero.WriteFile(memFs, filepath.Join(backups, "notes.txt"), []byte(""), 0644))
+	_, err = LoadLatestSnapshot(memFs, root, backups)
+	is.True(err != nil) // no snapshot folders
+
+	for _, createdAt := range []string{"2023-09-30_23-59-59", "2023-10-02_08-00-00", "2023-10-01_12-00-00"} {
+		s := &Snapshot{fs: memFs, rootFolder: root, folder: filepath.Join(backups, createdAt), CreatedAt: createdAt, Files: []*SnapshotFile{}}
+		is.NoErr(s.save())
+	}
+
+	latest, err := LoadLatestSnapshot(memFs, root, backups)
+	is.NoErr(err)
+	is.Equal(latest.CreatedAt, "2023-10-02_08-00-00")
+	is.Equal(latest.GetFolder(), filepath.Join(backups, "2023-10-02_08-00-00"))
+}
@@ -0,0 +1,392 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package migrations
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sveltinio/sveltin/utils"
+)
+
+// sveltinioComponentChange describes a breaking change to the interface of a
+// @sveltinio/* component introduced with the package version since.
+type sveltinioComponentChange struct {
+	pkg       string
+	since     string
+	component string
+	// new name for the component, empty when unchanged.
+	renameTo string
+	// old prop name -> new prop name.
+	renameProps map[string]string
+	// props dropped with no replacement. They are reported, never removed.
+	removedProps []string
+}
+
+// sveltinioChangelogs is the changelog for each @sveltinio/* package, the source
+// for the entries of sveltinioComponentChanges.
+var sveltinioChangelogs = map[string]string{
+	"@sveltinio/essentials": "https://github.com/sveltinio/components-library/blob/main/packages/essentials/CHANGELOG.md",
+	"@sveltinio/seo":        "https://github.com/sveltinio/components-library/blob/main/packages/seo/CHANGELOG.md",
+	"@sveltinio/widgets":    "https://github.com/sveltinio/components-library/blob/main/packages/widgets/CHANGELOG.md",
+}
+
+// changelog returns the link to the changelog section for the package version introducing the change.
+func (c *sveltinioComponentChange) changelog() string {
+	return sveltinioChangelogs[c.pkg] + "#" + strings.ReplaceAll(c.since, ".", "")
+}
+
+// sveltinioComponentChanges is the versioned mapping table used to rewrite
+// the @sveltinio/* components imports and props. Each entry is listed in the
+// package changelog (see sveltinioChangelogs) for the version since.
+var sveltinioComponentChanges = []*sveltinioComponentChange{
+	// @sveltinio/essentials
+	{pkg: "@sveltinio/essentials", since: "0.5.0", component: "Link", renameProps: map[string]string{"url": "href"}},
+	{pkg: "@sveltinio/essentials", since: "0.5.0", component: "ExternalLink", renameProps: map[string]string{"url": "href"}},
+	// @sveltinio/seo
+	{pkg: "@sveltinio/seo", since: "0.3.0", component: "PageMetaTags", renameProps: map[string]string{"page": "data"}},
+	{pkg: "@sveltinio/seo", since: "0.3.0", component: "JsonLdWebPage", renameProps: map[string]string{"page": "data"}},
+	{pkg: "@sveltinio/seo", since: "0.3.0", component: "JsonLdWebSite", renameProps: map[string]string{"website": "data"}},
+	{pkg: "@sveltinio/seo", since: "0.3.0", component: "JsonLdBreadcrumbs", renameProps: map[string]string{"url": "currentUrl"}, removedProps: []string{"baseURL"}},
+	// @sveltinio/widgets
+	{pkg: "@sveltinio/widgets", since: "0.5.0", component: "PageNavigator", renameTo: "PagesNavigator"},
+	{pkg: "@sveltinio/widgets", since: "0.5.0", component: "ScrollToTop", renameTo: "ScrollToTopButton"},
+	{pkg: "@sveltinio/widgets", since: "0.5.0", component: "TOC", renameProps: map[string]string{"data": "headings"}},
+	{pkg: "@sveltinio/widgets", since: "0.5.0", component: "Breadcrumbs", renameProps: map[string]string{"url": "currentUrl"}},
+}
+
+// sveltinioImportRe matches import declarations from the @sveltinio/* packages with components.
+var sveltinioImportRe = regexp.MustCompile(`import\s+([^;'"]+?)\s+from\s+['"](@sveltinio/(?:essentials|seo|widgets))['"]`)
+
+// getSveltinioComponentChanges returns the changes to apply for the package when
+// upgrading it from the current version to the target one.
+func getSveltinioComponentChanges(pkg, current, target string) ([]*sveltinioComponentChange, error) {
+	changes := []*sveltinioComponentChange{}
+	for _, c := range sveltinioComponentChanges {
+		if c.pkg != pkg {
+			continue
+		}
+		isNewer, err := utils.CompareSemVer(c.since, current)
+		if err != nil {
+			return nil, err
+		}
+		notAfterTarget, err := utils.CompareSemVer(c.since, target)
+		if err != nil {
+			return nil, err
+		}
+		if isNewer > 0 && notAfterTarget <= 0 {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+// componentWarning is a construct the rewrite could not change safely.
+type componentWarning struct {
+	line    int
+	message string
+}
+
+// componentUsage is a component imported from a @sveltinio/* package with changes to apply.
+type componentUsage struct {
+	change *sveltinioComponentChange
+	// name used to refer the component before and after the rewrite.
+	oldLocal string
+	newLocal string
+}
+
+// rewriteSveltinioComponents rewrites imports and props of the components listed in changes
+// (grouped by package name). It returns the rewritten content and the constructs to be
+// checked by hand.
+func rewriteSveltinioComponents(content string, changes map[string][]*sveltinioComponentChange) (string, []componentWarning) {
+	warnings := []componentWarning{}
+	usages := []*componentUsage{}
+
+	matches := sveltinioImportRe.FindAllStringSubmatchIndex(content, -1)
+	// walk backward so the offsets of the previous matches stay valid.
+	for i := len(matches) - 1; i >= 0; i-- {
+		match := matches[i]
+		clause := content[match[2]:match[3]]
+		pkg := content[match[4]:match[5]]
+		pkgChanges := changes[pkg]
+		if len(pkgChanges) == 0 || strings.HasPrefix(clause, "type ") {
+			continue
+		}
+
+		if !strings.HasPrefix(clause, "{") || !strings.HasSuffix(clause, "}") {
+			warnings = append(warnings, componentWarning{
+				line:    lineAt(content, match[0]),
+				message: fmt.Sprintf("default or namespace import from %s: check the usage of %s by hand", pkg, componentNames(pkgChanges)),
+			})
+			continue
+		}
+
+		newClause, clauseUsages := rewriteNamedImports(clause, pkgChanges)
+		usages = append(usages, clauseUsages...)
+		content = content[:match[2]] + newClause + content[match[3]:]
+	}
+
+	for _, u := range usages {
+		if u.oldLocal != u.newLocal {
+			content = renameComponentTags(content, u.oldLocal, u.newLocal)
+			if loc := regexp.MustCompile(`\b` + regexp.QuoteMeta(u.oldLocal) + `\b`).FindStringIndex(content); loc != nil {
+				warnings = append(warnings, componentWarning{
+					line:    lineAt(content, loc[0]),
+					message: fmt.Sprintf("%s is referenced outside of the markup: rename it to %s", u.oldLocal, u.newLocal),
+				})
+			}
+		}
+		var propsWarnings []componentWarning
+		content, propsWarnings = rewriteComponentProps(content, u)
+		warnings = append(warnings, propsWarnings...)
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool { return warnings[i].line < warnings[j].line })
+	return content, warnings
+}
+
+// rewriteNamedImports renames the components within a named imports clause,
+// e.g. { A, B as C }, preserving its formatting.
+func rewriteNamedImports(clause string, changes []*sveltinioComponentChange) (string, []*componentUsage) {
+	usages := []*componentUsage{}
+	specifiers := strings.Split(clause, ",")
+	for i, spec := range specifiers {
+		fields := strings.Fields(strings.Trim(spec, "{} \t\r\n"))
+		if len(fields) == 0 || fields[0] == "type" {
+			continue
+		}
+		name, alias := fields[0], ""
+		if len(fields) == 3 && fields[1] == "as" {
+			alias = fields[2]
+		}
+
+		for _, c := range changes {
+			if c.component != name {
+				continue
+			}
+			u := &componentUsage{change: c, oldLocal: name, newLocal: name}
+			if alias != "" {
+				u.oldLocal, u.newLocal = alias, alias
+			}
+			if c.renameTo != "" {
+				specifiers[i] = strings.Replace(spec, name, c.renameTo, 1)
+				if alias == "" {
+					u.newLocal = c.renameTo
+				}
+			}
+			usages = append(usages, u)
+		}
+	}
+	return strings.Join(specifiers, ","), usages
+}
+
+// renameComponentTags renames the opening and closing tags for the component.
+func renameComponentTags(content, oldName, newName string) string {
+	quoted := regexp.QuoteMeta(oldName)
+	openingTag := regexp.MustCompile(`<` + quoted + `([\s/>])`)
+	closingTag := regexp.MustCompile(`</` + quoted + `(\s*>)`)
+	content = openingTag.ReplaceAllString(content, "<"+newName+"${1}")
+	return closingTag.ReplaceAllString(content, "</"+newName+"${1}")
+}
+
+// componentAttribute is an attribute within a component opening tag.
+// Offsets are relative to the content.
+type componentAttribute struct {
+	name      string
+	start     int
+	end       int
+	shorthand bool
+	spread    bool
+}
+
+// rewriteComponentProps renames the props for all the opening tags of the component.
+func rewriteComponentProps(content string, u *componentUsage) (string, []componentWarning) {
+	warnings := []componentWarning{}
+	c := u.change
+	if len(c.renameProps) == 0 && len(c.removedProps) == 0 {
+		return content, warnings
+	}
+
+	tags := findComponentTags(content, u.newLocal)
+	for i := len(tags) - 1; i >= 0; i-- {
+		attrs := parseComponentAttributes(content, tags[i][0]+len(u.newLocal)+1, tags[i][1])
+		for j := len(attrs) - 1; j >= 0; j-- {
+			attr := attrs[j]
+			if attr.spread {
+				warnings = append(warnings, componentWarning{
+					line:    lineAt(content, attr.start),
+					message: fmt.Sprintf("spread props on %s: check the %s props by hand, see %s", u.newLocal, propNames(c), c.changelog()),
+				})
+				continue
+			}
+
+			prefix, name := "", attr.name
+			if strings.HasPrefix(name, "bind:") {
+				prefix, name = "bind:", strings.TrimPrefix(name, "bind:")
+			}
+			if newName, exists := c.renameProps[name]; exists {
+				replacement := prefix + newName
+				if attr.shorthand {
+					replacement = fmt.Sprintf("%s={%s}", newName, name)
+				}
+				content = content[:attr.start] + replacement + content[attr.end:]
+				continue
+			}
+			for _, removed := range c.removedProps {
+				if removed == name {
+					warnings = append(warnings, componentWarning{
+						line:    lineAt(content, attr.start),
+						message: fmt.Sprintf("the %s prop has been removed from %s, see %s", name, c.component, c.changelog()),
+					})
+				}
+			}
+		}
+	}
+	return content, warnings
+}
+
+// findComponentTags returns the offsets of the opening tags for the component.
+// Each tag spans from '<' to the closing '>' included.
+func findComponentTags(content, name string) [][2]int {
+	tags := [][2]int{}
+	openingTag := regexp.MustCompile(`<` + regexp.QuoteMeta(name) + `[\s/>]`)
+	for _, loc := range openingTag.FindAllStringIndex(content, -1) {
+		if end := skipToTagEnd(content, loc[0]+len(name)+1); end != -1 {
+			tags = append(tags, [2]int{loc[0], end})
+		}
+	}
+	return tags
+}
+
+// skipToTagEnd returns the offset following the '>' closing the tag, skipping
+// quoted values and expressions, or -1 if the tag is not closed.
+func skipToTagEnd(content string, from int) int {
+	depth := 0
+	var quote byte
+	for i := from; i < len(content); i++ {
+		ch := content[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'' || ch == '`':
+			quote = ch
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+		case ch == '>' && depth == 0:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// parseComponentAttributes returns the attributes within content[from:to].
+func parseComponentAttributes(content string, from, to int) []*componentAttribute {
+	attrs := []*componentAttribute{}
+	i := from
+	for i < to {
+		ch := content[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '/' || ch == '>':
+			i++
+		case ch == '{':
+			end := skipExpression(content, i)
+			expr := strings.TrimSpace(content[i+1 : end-1])
+			if strings.HasPrefix(expr, "...") {
+				attrs = append(attrs, &componentAttribute{start: i, end: end, spread: true})
+			} else {
+				attrs = append(attrs, &componentAttribute{name: expr, start: i, end: end, shorthand: true})
+			}
+			i = end
+		default:
+			start := i
+			for i < to && !strings.ContainsRune(" \t\r\n=/>", rune(content[i])) {
+				i++
+			}
+			attrs = append(attrs, &componentAttribute{name: content[start:i], start: start, end: i})
+			if i < to && content[i] == '=' {
+				i = skipAttributeValue(content, i+1)
+			}
+		}
+	}
+	return attrs
+}
+
+// skipExpression returns the offset following the '}' closing the expression starting at from.
+func skipExpression(content string, from int) int {
+	depth := 0
+	var quote byte
+	for i := from; i < len(content); i++ {
+		ch := content[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'' || ch == '`':
+			quote = ch
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(content)
+}
+
+// skipAttributeValue returns the offset following the attribute value starting at from.
+func skipAttributeValue(content string, from int) int {
+	if from >= len(content) {
+		return from
+	}
+	switch ch := content[from]; ch {
+	case '"', '\'':
+		// the value can contain expressions, e.g. "{a} {b}".
+		if end := strings.IndexByte(content[from+1:], ch); end != -1 {
+			return from + end + 2
+		}
+		return len(content)
+	case '{':
+		return skipExpression(content, from)
+	}
+	i := from
+	for i < len(content) && !strings.ContainsRune(" \t\r\n/>", rune(content[i])) {
+		i++
+	}
+	return i
+}
+
+func lineAt(content string, offset int) int {
+	return strings.Count(content[:offset], "\n") + 1
+}
+
+func componentNames(changes []*sveltinioComponentChange) string {
+	names := []string{}
+	for _, c := range changes {
+		names = append(names, c.component)
+	}
+	return strings.Join(names, ", ")
+}
+
+func propNames(c *sveltinioComponentChange) string {
+	names := []string{}
+	for name := range c.renameProps {
+		names = append(names, name)
+	}
+	names = append(names, c.removedProps...)
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,252 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func testComponentChanges(t *testing.T, pkg string) []*sveltinioComponentChange {
+	changes, err := getSveltinioComponentChanges(pkg, "0.0.1", "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return changes
+}
+
+func TestGetSveltinioComponentChanges(t *testing.T) {
+	is := is.New(t)
+
+	changes, err := getSveltinioComponentChanges("@sveltinio/widgets", "0.4.2", "0.5.0")
+	is.NoErr(err)
+	is.Equal(componentNames(changes), "PageNavigator, ScrollToTop, TOC, Breadcrumbs")
+
+	changes, err = getSveltinioComponentChanges("@sveltinio/widgets", "0.5.0", "0.6.0")
+	is.NoErr(err)
+	is.Equal(len(changes), 0) // already applied
+
+	changes, err = getSveltinioComponentChanges("@sveltinio/seo", "0.2.0", "0.2.9")
+	is.NoErr(err)
+	is.Equal(len(changes), 0) // not reached yet
+
+	for _, c := range sveltinioComponentChanges {
+		is.True(strings.HasPrefix(c.changelog(), "https://github.com/sveltinio/components-library/blob/main/packages/")) // changelog for every package
+	}
+	is.Equal(sveltinioComponentChanges[0].changelog(), "https://github.com/sveltinio/components-library/blob/main/packages/essentials/CHANGELOG.md#050")
+}
+
+func TestRewriteNamedImports(t *testing.T) {
+	tests := []struct {
+		name     string
+		clause   string
+		expected string
+		usages   [][2]string
+	}{
+		{
+			name:     "renamed component",
+			clause:   "{ PageNavigator }",
+			expected: "{ PagesNavigator }",
+			usages:   [][2]string{{"PageNavigator", "PagesNavigator"}},
+		},
+		{
+			name:     "aliased component keeps the alias",
+			clause:   "{ ScrollToTop as Top, TOC }",
+			expected: "{ ScrollToTopButton as Top, TOC }",
+			usages:   [][2]string{{"Top", "Top"}, {"TOC", "TOC"}},
+		},
+		{
+			name:     "multiline clause keeps its formatting",
+			clause:   "{\n\tPageNavigator,\n\tCard,\n}",
+			expected: "{\n\tPagesNavigator,\n\tCard,\n}",
+			usages:   [][2]string{{"PageNavigator", "PagesNavigator"}},
+		},
+		{
+			name:     "type imports are skipped",
+			clause:   "{ type TOC, Card }",
+			expected: "{ type TOC, Card }",
+		},
+	}
+
+	changes := testComponentChanges(t, "@sveltinio/widgets")
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, usages := rewriteNamedImports(tc.clause, changes)
+			is.Equal(got, tc.expected)
+			is.Equal(len(usages), len(tc.usages))
+			for i, u := range usages {
+				is.Equal([2]string{u.oldLocal, u.newLocal}, tc.usages[i])
+			}
+		})
+	}
+}
+
+func TestRenameComponentTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "opening and closing tags",
+			input:    "<PageNavigator {prev} {next}>\n\t<span>x</span>\n</PageNavigator >",
+			expected: "<PagesNavigator {prev} {next}>\n\t<span>x</span>\n</PagesNavigator >",
+		},
+		{
+			name:     "self-closing tags",
+			input:    "<PageNavigator/>\n<PageNavigator\n\tprev={p} />",
+			expected: "<PagesNavigator/>\n<PagesNavigator\n\tprev={p} />",
+		},
+		{
+			name:     "components with the same prefix are not renamed",
+			input:    "<PageNavigatorItem /><PageNavigator>",
+			expected: "<PageNavigatorItem /><PagesNavigator>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(renameComponentTags(tc.input, "PageNavigator", "PagesNavigator"), tc.expected)
+		})
+	}
+}
+
+func TestRewriteComponentProps(t *testing.T) {
+	tests := []struct {
+		name      string
+		pkg       string
+		component string
+		local     string
+		input     string
+		expected  string
+		warnings  []string
+	}{
+		{
+			name:      "renamed prop",
+			pkg:       "@sveltinio/essentials",
+			component: "Link",
+			local:     "Link",
+			input:     `<Link url="/about" class="link">About</Link>`,
+			expected:  `<Link href="/about" class="link">About</Link>`,
+		},
+		{
+			name:      "shorthand prop",
+			pkg:       "@sveltinio/essentials",
+			component: "Link",
+			local:     "Link",
+			input:     `<Link {url}>About</Link>`,
+			expected:  `<Link href={url}>About</Link>`,
+		},
+		{
+			name:      "bound prop",
+			pkg:       "@sveltinio/widgets",
+			component: "TOC",
+			local:     "TOC",
+			input:     `<TOC bind:data />`,
+			expected:  `<TOC bind:headings />`,
+		},
+		{
+			name:      "self-closing tags and expressions with '>'",
+			pkg:       "@sveltinio/essentials",
+			component: "Link",
+			local:     "Link",
+			input:     "<Link url={a > b ? x : y}/>\n<Link\n\turl='/'\n/>",
+			expected:  "<Link href={a > b ? x : y}/>\n<Link\n\thref='/'\n/>",
+		},
+		{
+			name:      "aliased component",
+			pkg:       "@sveltinio/essentials",
+			component: "Link",
+			local:     "A",
+			input:     `<A url="/" /><Link url="/" />`,
+			expected:  `<A href="/" /><Link url="/" />`,
+		},
+		{
+			name:      "spread props are reported",
+			pkg:       "@sveltinio/essentials",
+			component: "Link",
+			local:     "Link",
+			input:     "<Link\n\t{...props} url=\"/\" />",
+			expected:  "<Link\n\t{...props} href=\"/\" />",
+			warnings:  []string{"2: spread props on Link: check the url props by hand, see https://github.com/sveltinio/components-library/blob/main/packages/essentials/CHANGELOG.md#050"},
+		},
+		{
+			name:      "removed props are reported and kept",
+			pkg:       "@sveltinio/seo",
+			component: "JsonLdBreadcrumbs",
+			local:     "JsonLdBreadcrumbs",
+			input:     `<JsonLdBreadcrumbs baseURL={base} url={current} />`,
+			expected:  `<JsonLdBreadcrumbs baseURL={base} currentUrl={current} />`,
+			warnings:  []string{"1: the baseURL prop has been removed from JsonLdBreadcrumbs, see https://github.com/sveltinio/components-library/blob/main/packages/seo/CHANGELOG.md#030"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var change *sveltinioComponentChange
+			for _, c := range testComponentChanges(t, tc.pkg) {
+				if c.component == tc.component {
+					change = c
+				}
+			}
+			is.True(change != nil)
+
+			got, warnings := rewriteComponentProps(tc.input, &componentUsage{change: change, oldLocal: tc.local, newLocal: tc.local})
+			is.Equal(got, tc.expected)
+			is.Equal(formatComponentWarnings(warnings), tc.warnings)
+		})
+	}
+}
+
+func TestRewriteSveltinioComponents(t *testing.T) {
+	is := is.New(t)
+
+	changes := map[string][]*sveltinioComponentChange{
+		"@sveltinio/essentials": testComponentChanges(t, "@sveltinio/essentials"),
+		"@sveltinio/widgets":    testComponentChanges(t, "@sveltinio/widgets"),
+	}
+	input := `<script lang="ts">
+	import { Link as L } from '@sveltinio/essentials';
+	import { PageNavigator, TOC } from "@sveltinio/widgets";
+	import * as seo from '@sveltinio/seo';
+	const nav = PageNavigator;
+</script>
+
+<L url="/">Home</L>
+<TOC {data} />
+<PageNavigator {prev} {next} />
+`
+	expected := `<script lang="ts">
+	import { Link as L } from '@sveltinio/essentials';
+	import { PagesNavigator, TOC } from "@sveltinio/widgets";
+	import * as seo from '@sveltinio/seo';
+	const nav = PageNavigator;
+</script>
+
+<L href="/">Home</L>
+<TOC headings={data} />
+<PagesNavigator {prev} {next} />
+`
+	got, warnings := rewriteSveltinioComponents(input, changes)
+	is.Equal(got, expected)
+	is.Equal(formatComponentWarnings(warnings), []string{"5: PageNavigator is referenced outside of the markup: rename it to PagesNavigator"})
+
+	changes["@sveltinio/widgets"] = nil
+	changes["@sveltinio/essentials"] = testComponentChanges(t, "@sveltinio/essentials")
+	_, warnings = rewriteSveltinioComponents(`import Essentials from '@sveltinio/essentials';`, changes)
+	is.Equal(formatComponentWarnings(warnings), []string{"1: default or namespace import from @sveltinio/essentials: check the usage of Link, ExternalLink by hand"})
+}
+
+func formatComponentWarnings(warnings []componentWarning) []string {
+	var list []string
+	for _, w := range warnings {
+		list = append(list, fmt.Sprintf("%d: %s", w.line, w.message))
+	}
+	return list
+}
@@ -0,0 +1,7 @@
+import type { IWebPageMetadata } from '@sveltinio/seo/types';
+
+export type ResourceContent = {
+	resource: string;
+	metadata: IWebPageMetadata;
+	html?: string;
+};
@@ -0,0 +1,12 @@
+import { theme } from '../../sveltin.json';
+
+const themeConfig = {
+	name: 'sveltin_theme',
+	version: '0.1',
+	license: 'MIT',
+	author: {
+		name: 'sveltin',
+	},
+};
+
+export { themeConfig };
@@ -0,0 +1,3 @@
+const sveltinVersion = '0.10.0';
+
+export { sveltinVersion };
@@ -0,0 +1,7 @@
+import type { IMenuItem } from '@sveltinio/seo/types';
+
+const menu: Array<IMenuItem> = [
+	{ identifier: 'posts', name: 'Posts', url: '/posts', weight: 1 },
+];
+
+export { menu };
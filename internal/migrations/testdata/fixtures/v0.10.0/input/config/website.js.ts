@@ -0,0 +1,20 @@
+import type { IWebSite } from '@sveltinio/seo/types';
+
+const website: IWebSite = {
+	name: 'blog',
+	baseURL: 'http://blog.com',
+	language: 'en',
+	title: 'My Blog',
+	description: 'A blog made with sveltin',
+	keywords: 'sveltin, blog',
+	contactEmail: 'me@blog.com',
+	webmaster: {
+		name: 'me',
+	},
+	sitemap: {
+		changeFreq: 'monthly',
+		priority: 0.5,
+	},
+};
+
+export { website };
@@ -0,0 +1,21 @@
+import { defineMDSveXConfig as defineConfig } from 'mdsvex';
+import remarkExternalLinks from 'remark-external-links';
+import remarkSlug from 'remark-slug';
+import rehypeSlug from 'rehype-slug';
+import rehypeAutoLinkHeadings from 'rehype-autolink-headings';
+import headings from './src/lib/utils/headings.js';
+
+const mdsvexConfig = defineConfig({
+	extensions: ['.svelte.md', '.md', '.svx'],
+	remarkPlugins: [
+		headings,
+		remarkSlug,
+		[remarkExternalLinks, { target: '_blank', rel: 'noopener' }],
+	],
+	rehypePlugins: [
+		rehypeSlug,
+		[rehypeAutoLinkHeadings, { behavior: 'wrap' }],
+	],
+});
+
+export default mdsvexConfig;
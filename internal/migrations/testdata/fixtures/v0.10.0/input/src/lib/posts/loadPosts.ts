@@ -0,0 +1,6 @@
+import type { ContentEntry } from 'src/sveltin';
+import { ToSlug } from '$lib/utils/strings.js';
+
+export async function list(): Promise<Array<ContentEntry>> {
+	return [{ slug: ToSlug('Hello World') }];
+}
@@ -0,0 +1,6 @@
+import type { ContentEntry } from 'src/sveltin';
+import { list } from '$lib/posts/loadPosts';
+
+export async function load(): Promise<{ items: Array<ContentEntry> }> {
+	return { items: await list() };
+}
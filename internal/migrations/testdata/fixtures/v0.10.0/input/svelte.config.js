@@ -0,0 +1,15 @@
+import adapter from '@sveltejs/adapter-static';
+import { mdsvex } from 'mdsvex';
+import mdsvexConfig from './mdsvex.config.js';
+
+/** @type {import('@sveltejs/kit').Config} */
+const config = {
+	extensions: ['.svelte', ...mdsvexConfig.extensions],
+	preprocess: [mdsvex(mdsvexConfig)],
+	kit: {
+		adapter: adapter(),
+		trailingSlash: 'always',
+	},
+};
+
+export default config;
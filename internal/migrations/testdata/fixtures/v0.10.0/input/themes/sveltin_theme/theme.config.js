@@ -0,0 +1,10 @@
+const config = {
+	name: 'sveltin_theme',
+	version: '0.1',
+	license: 'MIT',
+	author: {
+		name: 'sveltin',
+	},
+};
+
+export default config;
@@ -0,0 +1,15 @@
+import { sveltekit } from '@sveltejs/kit/vite';
+import type { UserConfig } from 'vite';
+import path from 'path';
+
+const config: UserConfig = {
+	plugins: [sveltekit()],
+	resolve: {
+		alias: {
+			$config: path.resolve('./config'),
+			$themes: path.resolve('./themes'),
+		},
+	},
+};
+
+export default config;
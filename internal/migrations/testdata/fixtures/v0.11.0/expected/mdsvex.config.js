@@ -0,0 +1,19 @@
+import { defineMDSveXConfig as defineConfig } from 'mdsvex';
+import rehypeSlug from 'rehype-slug';
+import rehypeAutoLinkHeadings from 'rehype-autolink-headings';
+import headings from '@sveltinio/remark-headings';
+import rehypeExternalLinks from 'rehype-external-links';
+
+const mdsvexConfig = defineConfig({
+	extensions: ['.svelte.md', '.md', '.svx'],
+	remarkPlugins: [
+		headings,
+	],
+	rehypePlugins: [
+		rehypeSlug,
+		[rehypeAutoLinkHeadings, { behavior: 'wrap' }],
+		[rehypeExternalLinks, { target: '_blank', rel: ['noopener', 'noreferrer'] }],
+	],
+});
+
+export default mdsvexConfig;
@@ -0,0 +1,6 @@
+import type { Sveltin } from '$sveltin';
+import { list } from '$lib/posts/loadPosts';
+
+export async function load(): Promise<{ items: Array<Sveltin.ResourceContent> }> {
+	return { items: await list() };
+}
@@ -0,0 +1,14 @@
+import adapter from '@sveltejs/adapter-static';
+import { mdsvex } from 'mdsvex';
+import mdsvexConfig from './mdsvex.config.js';
+
+/** @type {import('@sveltejs/kit').Config} */
+const config = {
+	extensions: ['.svelte', ...mdsvexConfig.extensions],
+	preprocess: [mdsvex(mdsvexConfig)],
+	kit: {
+		adapter: adapter(),
+	},
+};
+
+export default config;
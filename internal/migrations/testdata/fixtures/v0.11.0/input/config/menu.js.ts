@@ -0,0 +1,7 @@
+import type { Sveltin } from '$sveltin';
+
+const menu: Array<Sveltin.MenuItem> = [
+	{ identifier: 'posts', name: 'Posts', url: '/posts', weight: 1 },
+];
+
+export { menu };
@@ -0,0 +1,27 @@
+import type { Sveltin } from '$sveltin';
+
+const website: Sveltin.WebSite = {
+	name: 'blog',
+	baseURL: 'http://blog.com',
+	language: 'en',
+	title: 'My Blog',
+	description: 'A blog made with sveltin',
+	keywords: ['sveltin', 'blog'],
+	contactEmail: 'me@blog.com',
+	creator: {
+		name: 'me',
+	},
+
+	/**
+	 * ! [sveltin migrate] @IMPORTANT
+	 * sitemap has been moved as prop out from WebSite types.
+	 *
+	 * It is now configured in sveltin.json file. Reflect your sitemap config there.
+	 */
+	sitemap: {
+		changeFreq: 'monthly',
+		priority: 0.5,
+	},
+};
+
+export { website };
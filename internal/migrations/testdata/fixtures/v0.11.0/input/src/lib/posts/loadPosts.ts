@@ -0,0 +1,6 @@
+import type { Sveltin } from '$sveltin';
+import { toSlug } from '$lib/utils/strings.js';
+
+export async function list(): Promise<Array<Sveltin.ResourceContent>> {
+	return [{ slug: toSlug('Hello World') }];
+}
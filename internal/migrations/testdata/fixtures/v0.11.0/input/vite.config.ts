@@ -0,0 +1,16 @@
+import { sveltekit } from '@sveltejs/kit/vite';
+import type { UserConfig } from 'vite';
+import path from 'path';
+
+const config: UserConfig = {
+	plugins: [sveltekit()],
+	resolve: {
+		alias: {
+			$sveltin: path.resolve('./src/sveltin'),
+			$config: path.resolve('./config'),
+			$themes: path.resolve('./themes'),
+		},
+	},
+};
+
+export default config;
@@ -0,0 +1,5 @@
+import { sveltin } from '../sveltin.json';
+
+const sveltinVersion = sveltin.version;
+
+export { sveltinVersion };
@@ -0,0 +1,5 @@
+import type { Sveltin } from '$sveltin';
+
+const menu: Array<Sveltin.MenuItem> = [];
+
+export { menu };
@@ -0,0 +1,12 @@
+import type { Sveltin } from '$sveltin';
+
+const website: Sveltin.WebSite = {
+	name: 'site',
+	baseURL: 'http://site.com',
+	language: 'en',
+	title: 'My Site',
+	description: 'A website made with sveltin',
+	keywords: ['sveltin'],
+};
+
+export { website };
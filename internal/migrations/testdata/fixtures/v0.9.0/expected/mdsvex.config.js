@@ -0,0 +1,7 @@
+import { defineMDSveXConfig as defineConfig } from 'mdsvex';
+
+const mdsvexConfig = defineConfig({
+	extensions: ['.svelte.md', '.md', '.svx'],
+});
+
+export default mdsvexConfig;
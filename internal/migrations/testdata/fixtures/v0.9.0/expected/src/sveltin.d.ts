@@ -0,0 +1,4 @@
+export type ResourceContent = {
+	resource: string;
+	html?: string;
+};
@@ -0,0 +1,7 @@
+/** @type {import('@sveltejs/kit').Config} */
+const config = {
+	kit: {
+	},
+};
+
+export default config;
@@ -0,0 +1,8 @@
+import { theme } from '../../sveltin.json';
+
+const themeConfig = {
+	name: 'blank_theme',
+	version: '0.1',
+};
+
+export { themeConfig };
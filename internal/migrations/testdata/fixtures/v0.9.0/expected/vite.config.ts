@@ -0,0 +1,14 @@
+import { sveltekit } from '@sveltejs/kit/vite';
+import path from 'path';
+
+const config = {
+	plugins: [sveltekit()],
+	resolve: {
+		alias: {
+			$sveltin: path.resolve('./src/sveltin'),
+			$config: path.resolve('./config'),
+		},
+	},
+};
+
+export default config;
@@ -0,0 +1,3 @@
+const sveltinVersion = '0.9.0';
+
+export { sveltinVersion };
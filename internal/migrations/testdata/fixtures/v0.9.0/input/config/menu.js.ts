@@ -0,0 +1,5 @@
+import type { IMenuItem } from '@sveltinio/seo/types';
+
+const menu: Array<IMenuItem> = [];
+
+export { menu };
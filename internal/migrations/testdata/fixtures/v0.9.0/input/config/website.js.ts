@@ -0,0 +1,12 @@
+import type { IWebSite } from '@sveltinio/seo/types';
+
+const website: IWebSite = {
+	name: 'site',
+	baseURL: 'http://site.com',
+	language: 'en',
+	title: 'My Site',
+	description: 'A website made with sveltin',
+	keywords: 'sveltin',
+};
+
+export { website };
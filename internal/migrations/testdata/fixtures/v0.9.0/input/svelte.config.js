@@ -0,0 +1,8 @@
+/** @type {import('@sveltejs/kit').Config} */
+const config = {
+	kit: {
+		trailingSlash: 'always',
+	},
+};
+
+export default config;
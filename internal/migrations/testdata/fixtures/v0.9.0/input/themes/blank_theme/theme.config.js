@@ -0,0 +1,6 @@
+const config = {
+	name: 'blank_theme',
+	version: '0.1',
+};
+
+export default config;
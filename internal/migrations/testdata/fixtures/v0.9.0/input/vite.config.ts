@@ -0,0 +1,13 @@
+import { sveltekit } from '@sveltejs/kit/vite';
+import path from 'path';
+
+const config = {
+	plugins: [sveltekit()],
+	resolve: {
+		alias: {
+			$config: path.resolve('./config'),
+		},
+	},
+};
+
+export default config;
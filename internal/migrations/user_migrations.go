@@ -0,0 +1,302 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+// Folders, relative to the project root, never visited when looking for the files matching
+// a user migration target. Folders with the same name at other depths are visited.
+var userMigrationSkipFolders = []string{"node_modules", ".git", ".svelte-kit", "build", "backups"}
+
+// UserMigrationsFile is the struct used to map the YAML file with user-defined migrations.
+type UserMigrationsFile struct {
+	Migrations  []*UserMigration        `mapstructure:"migrations" validate:"required_without=Frontmatter,dive"`
+	Frontmatter []*FrontmatterMigration `mapstructure:"frontmatter" validate:"required_without=Migrations,dive"`
+}
+
+// UserMigration is the struct representing a user-defined migration.
+//
+// Target is a glob, relative to the project root, matching the files to migrate ('**' matches any folder).
+// Files containing the Gatekeeper string are skipped. Files are migrated only if at least one
+// of the Triggers regexes (or the rules triggers when empty) matches a line.
+type UserMigration struct {
+	Name       string               `mapstructure:"name" validate:"required"`
+	Target     string               `mapstructure:"target" validate:"required"`
+	Gatekeeper string               `mapstructure:"gatekeeper"`
+	Triggers   []string             `mapstructure:"triggers"`
+	Rules      []*UserMigrationRule `mapstructure:"rules" validate:"required,dive"`
+}
+
+// UserMigrationRule is the struct representing a rule for a user-defined migration.
+//
+// When ReplaceFullLine is true, lines matching Trigger are replaced by Replace.
+// Otherwise only the matching text is. In both cases Replace can refer to
+// the regex submatches ($1, ${name}).
+type UserMigrationRule struct {
+	Trigger         string `mapstructure:"trigger" validate:"required"`
+	Replace         string `mapstructure:"replace"`
+	ReplaceFullLine bool   `mapstructure:"replaceFullLine"`
+}
+
+// LoadUserMigrations reads and validates the YAML file with user-defined migrations.
+func LoadUserMigrations(fs afero.Fs, pathToFile string) (*UserMigrationsFile, error) {
+	v := viper.New()
+	v.SetFs(fs)
+	v.SetConfigFile(pathToFile)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	rulesFile := &UserMigrationsFile{}
+	if err := v.Unmarshal(rulesFile); err != nil {
+		return nil, err
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(rulesFile); err != nil {
+		return nil, fmt.Errorf("not valid user migrations file %s: %w", pathToFile, err)
+	}
+
+	for _, um := range rulesFile.Migrations {
+		if _, err := globToRegexp(um.Target); err != nil {
+			return nil, fmt.Errorf("%s: not valid target %q: %w", um.Name, um.Target, err)
+		}
+		for _, t := range um.Triggers {
+			if _, err := regexp.Compile(t); err != nil {
+				return nil, fmt.Errorf("%s: not valid trigger %q: %w", um.Name, t, err)
+			}
+		}
+		for _, r := range um.Rules {
+			if _, err := regexp.Compile(r.Trigger); err != nil {
+				return nil, fmt.Errorf("%s: not valid trigger %q: %w", um.Name, r.Trigger, err)
+			}
+		}
+	}
+
+	return rulesFile, nil
+}
+
+//=============================================================================
+
+// ApplyUserMigration is the struct representing the migration running a user-defined migration.
+type ApplyUserMigration struct {
+	Mediator   IMigrationMediator
+	Services   *MigrationServices
+	Data       *MigrationData
+	Definition *UserMigration
+}
+
+// NewUserMigration returns the migration for the user-defined migration. TargetPath for data is the project root folder.
+func NewUserMigration(migrationManager *MigrationManager, services *MigrationServices, data *MigrationData, definition *UserMigration) IMigration {
+	data.Name = definition.Name
+	return &ApplyUserMigration{
+		Mediator:   migrationManager,
+		Services:   services,
+		Data:       data,
+		Definition: definition,
+	}
+}
+
+// implements IMigration interface.
+func (m *ApplyUserMigration) getServices() *MigrationServices { return m.Services }
+func (m *ApplyUserMigration) getData() *MigrationData         { return m.Data }
+
+// Migrate return error if migration execution over up and down methods fails (IMigration interface).
+func (m ApplyUserMigration) Migrate() error {
+	if err := m.up(); err != nil {
+		return err
+	}
+	if err := m.down(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ApplyUserMigration) up() error {
+	if !m.Mediator.canRun(m) {
+		return nil
+	}
+
+	files, err := m.getTargetFiles()
+	if err != nil {
+		return err
+	}
+
+	migrationTriggers := m.Definition.Triggers
+	if len(migrationTriggers) == 0 {
+		for _, r := range m.Definition.Rules {
+			migrationTriggers = append(migrationTriggers, r.Trigger)
+		}
+	}
+
+	for _, file := range files {
+		fileContent, err := retrieveFileContent(m.getServices().fs, file)
+		if err != nil {
+			return err
+		}
+
+		if m.Definition.Gatekeeper != "" && !mustMigrate(fileContent, m.Definition.Gatekeeper) {
+			continue
+		}
+
+		if patternsMatched(fileContent, migrationTriggers, findStringMatcher) {
+			localFilePath :=
+				strings.Replace(file, m.Data.TargetPath, "", 1)
+			m.getServices().logger.Info(fmt.Sprintf("Migrating %s (%s)", localFilePath, m.Definition.Name))
+			migratedContent, err := m.runMigration(fileContent, file)
+			if err != nil {
+				return err
+			}
+			if err := writeFile(m, file, migratedContent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ApplyUserMigration) down() error {
+	if err := m.Mediator.notifyAboutCompletion(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ApplyUserMigration) allowUp() error {
+	if err := m.up(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ApplyUserMigration) runMigration(content []byte, file string) ([]byte, error) {
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		rules := []*migrationRule{}
+		for _, r := range m.Definition.Rules {
+			rules = append(rules, newUserMigrationRule(line, r))
+		}
+		if res, ok := applyMigrationRules(m, rules); ok {
+			lines[i] = res
+		} else {
+			lines[i] = line
+		}
+	}
+	output := strings.Join(lines, "\n")
+	return []byte(output), nil
+}
+
+// getTargetFiles returns the files within the project root folder matching the target glob.
+func (m *ApplyUserMigration) getTargetFiles() ([]string, error) {
+	matcher, err := globToRegexp(m.Definition.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	walkFunc := func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(m.Data.TargetPath, file)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			for _, name := range userMigrationSkipFolders {
+				if filepath.ToSlash(relPath) == name {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+		if matcher.MatchString(filepath.ToSlash(relPath)) {
+			files = append(files, file)
+		}
+		return nil
+	}
+
+	if err := afero.Walk(m.getServices().fs, m.Data.TargetPath, walkFunc); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+//=============================================================================
+
+func newUserMigrationRule(line string, r *UserMigrationRule) *migrationRule {
+	expression := regexp.MustCompile(r.Trigger)
+	return &migrationRule{
+		value:           line,
+		trigger:         r.Trigger,
+		replaceFullLine: r.ReplaceFullLine,
+		replacerFunc: func(s string) string {
+			if r.ReplaceFullLine {
+				submatches := expression.FindStringSubmatchIndex(s)
+				return string(expression.ExpandString(nil, r.Replace, s, submatches))
+			}
+			return expression.ReplaceAllString(s, r.Replace)
+		},
+	}
+}
+
+// globToRegexp converts a glob pattern to a regular expression.
+// '**/' matches zero or more folders, '*' and '?' do not match the path separator,
+// '{a,b}' matches any of the alternatives.
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	inBraces := false
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				if i+2 < len(glob) && glob[i+2] == '/' {
+					sb.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					sb.WriteString(".*")
+					i++
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '{':
+			inBraces = true
+			sb.WriteString("(?:")
+		case '}':
+			inBraces = false
+			sb.WriteString(")")
+		case ',':
+			if inBraces {
+				sb.WriteString("|")
+			} else {
+				sb.WriteString(",")
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,241 @@
+package migrations
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/sveltinio/sveltin/config"
+	"github.com/sveltinio/sveltin/internal/fsm"
+	"github.com/sveltinio/sveltin/internal/pathmaker"
+	"github.com/sveltinio/yinlog"
+)
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob       string
+		matches    []string
+		notMatches []string
+	}{
+		{
+			glob:       "src/*.svelte",
+			matches:    []string{"src/App.svelte"},
+			notMatches: []string{"src/lib/App.svelte", "src/App.svelte.bak", "App.svelte"},
+		},
+		{
+			glob:       "src/**/*.svelte",
+			matches:    []string{"src/App.svelte", "src/routes/+page.svelte", "src/routes/posts/[slug]/+page.svelte"},
+			notMatches: []string{"themes/App.svelte", "src/routes/+page.ts"},
+		},
+		{
+			glob:       "**/*.{ts,js}",
+			matches:    []string{"vite.config.ts", "src/lib/a.js"},
+			notMatches: []string{"src/lib/a.svelte", "src/lib/a.tsx"},
+		},
+		{
+			glob:       "src/lib/?.ts",
+			matches:    []string{"src/lib/a.ts"},
+			notMatches: []string{"src/lib/ab.ts", "src/lib//.ts"},
+		},
+		{
+			glob:       "content/**",
+			matches:    []string{"content/posts/hello/index.svx"},
+			notMatches: []string{"contents/a.svx"},
+		},
+		{
+			glob:       "src/(app)/[slug]+page.ts",
+			matches:    []string{"src/(app)/[slug]+page.ts"},
+			notMatches: []string{"src/app/s+page.ts", "src/(app)/[slug]++page.ts"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.glob, func(t *testing.T) {
+			is := is.New(t)
+
+			re, err := globToRegexp(tc.glob)
+			is.NoErr(err)
+			for _, p := range tc.matches {
+				is.True(re.MatchString(p)) // should match
+			}
+			for _, p := range tc.notMatches {
+				is.True(!re.MatchString(p)) // should not match
+			}
+		})
+	}
+}
+
+func TestUserMigrationRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *UserMigrationRule
+		input    string
+		expected string
+	}{
+		{
+			name:     "replace the matching text",
+			rule:     &UserMigrationRule{Trigger: `url=`, Replace: `href=`},
+			input:    "<Link url={u} />\n<a url=\"/\">",
+			expected: "<Link href={u} />\n<a href=\"/\">",
+		},
+		{
+			name:     "replace the matching text with submatches",
+			rule:     &UserMigrationRule{Trigger: `(\w+)Store\b`, Replace: `${1}State`},
+			input:    "import { pageStore, menuStore } from '$lib/stores';",
+			expected: "import { pageState, menuState } from '$lib/stores';",
+		},
+		{
+			name:     "replace the full line with submatches",
+			rule:     &UserMigrationRule{Trigger: `^import (\w+) from '(.+)\.svelte';$`, Replace: `import $1 from '$2/index.svelte';`, ReplaceFullLine: true},
+			input:    "import Card from '$lib/Card.svelte';\nconst a = 1;",
+			expected: "import Card from '$lib/Card/index.svelte';\nconst a = 1;",
+		},
+		{
+			name:     "replace the full line with named submatches",
+			rule:     &UserMigrationRule{Trigger: `export let (?P<name>\w+)`, Replace: `let { ${name} } = $$props();`, ReplaceFullLine: true},
+			input:    "\texport let title = 'x';",
+			expected: "let { title } = $props();",
+		},
+		{
+			name:     "lines not matching are kept",
+			rule:     &UserMigrationRule{Trigger: `^nope$`, Replace: ``, ReplaceFullLine: true},
+			input:    "a\r\nb\r\n",
+			expected: "a\r\nb\r\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			m := newTestUserMigration(afero.NewMemMapFs(), t, &UserMigration{Name: "test", Target: "**", Rules: []*UserMigrationRule{tc.rule}})
+			got, err := m.runMigration([]byte(tc.input), "file")
+			is.NoErr(err)
+			is.Equal(string(got), tc.expected)
+		})
+	}
+}
+
+func TestUserMigrationGatekeeperAndTriggers(t *testing.T) {
+	tests := []struct {
+		name     string
+		def      *UserMigration
+		input    string
+		migrated bool
+	}{
+		{
+			name:     "rules triggers when no triggers are set",
+			def:      &UserMigration{Rules: []*UserMigrationRule{{Trigger: `old`, Replace: `new`}}},
+			input:    "const old = 1;",
+			migrated: true,
+		},
+		{
+			name:  "no rule trigger matches",
+			def:   &UserMigration{Rules: []*UserMigrationRule{{Trigger: `old`, Replace: `new`}}},
+			input: "const a = 1;",
+		},
+		{
+			name:  "triggers set and not matching",
+			def:   &UserMigration{Triggers: []string{`^<script`}, Rules: []*UserMigrationRule{{Trigger: `old`, Replace: `new`}}},
+			input: "const old = 1;",
+		},
+		{
+			name:     "triggers set and matching",
+			def:      &UserMigration{Triggers: []string{`^<script`}, Rules: []*UserMigrationRule{{Trigger: `old`, Replace: `new`}}},
+			input:    "<script>\nconst old = 1;\n</script>",
+			migrated: true,
+		},
+		{
+			name:  "gatekeeper found",
+			def:   &UserMigration{Gatekeeper: "const new", Rules: []*UserMigrationRule{{Trigger: `old`, Replace: `new`}}},
+			input: "const old = 1;\nconst new = 2;",
+		},
+		{
+			name:     "gatekeeper not found",
+			def:      &UserMigration{Gatekeeper: "const new", Rules: []*UserMigrationRule{{Trigger: `old`, Replace: `new`}}},
+			input:    "const old = 1;",
+			migrated: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			memFs := afero.NewMemMapFs()
+			tc.def.Name, tc.def.Target = "test", "src/*.ts"
+			m := newTestUserMigration(memFs, t, tc.def)
+			file := filepath.Join(m.Data.TargetPath, "src", "a.ts")
+			is.NoErr(afero.WriteFile(memFs, file, []byte(tc.input), 0644))
+
+			is.NoErr(m.Migrate())
+			is.Equal(len(m.Services.changes) == 1, tc.migrated)
+		})
+	}
+}
+
+func TestUserMigrationTargetFiles(t *testing.T) {
+	is := is.New(t)
+
+	memFs := afero.NewMemMapFs()
+	m := newTestUserMigration(memFs, t, &UserMigration{Name: "test", Target: "**/*.ts"})
+	for _, f := range []string{
+		"vite.config.ts",
+		"src/lib/a.ts",
+		"src/lib/build/b.ts",
+		"src/routes/backups/+page.ts",
+		"build/c.ts",
+		"backups/d.ts",
+		"node_modules/e/index.ts",
+		"src/lib/a.svelte",
+	} {
+		is.NoErr(afero.WriteFile(memFs, filepath.Join(m.Data.TargetPath, f), []byte(""), 0644))
+	}
+
+	files, err := m.getTargetFiles()
+	is.NoErr(err)
+	relPaths := []string{}
+	for _, f := range files {
+		rel, err := filepath.Rel(m.Data.TargetPath, f)
+		is.NoErr(err)
+		relPaths = append(relPaths, filepath.ToSlash(rel))
+	}
+	sort.Strings(relPaths)
+	// skipped folders are anchored to the project root.
+	is.Equal(relPaths, []string{"src/lib/a.ts", "src/lib/build/b.ts", "src/routes/backups/+page.ts", "vite.config.ts"})
+}
+
+func newTestUserMigration(memFs afero.Fs, t *testing.T, def *UserMigration) *ApplyUserMigration {
+	pathMaker := newTestPathMaker(t)
+	services := newTestServices(memFs, pathMaker)
+	data := &MigrationData{TargetPath: pathMaker.GetRootFolder()}
+	return NewUserMigration(NewMigrationManager(), services, data, def).(*ApplyUserMigration)
+}
+
+// newTestServices returns the migration services for the file system, the log is discarded.
+func newTestServices(memFs afero.Fs, pathMaker *pathmaker.SveltinPathMaker) *MigrationServices {
+	logger := yinlog.New()
+	logger.SetPrinter(&yinlog.TextPrinter{Writer: io.Discard, Options: &yinlog.PrinterOptions{}})
+	return NewMigrationServices(memFs, fsm.NewSveltinFSManager(pathMaker), pathMaker, logger)
+}
+
+// newTestPathMaker returns the path maker for the default sveltin settings.
+func newTestPathMaker(t *testing.T) *pathmaker.SveltinPathMaker {
+	is := is.New(t)
+
+	var settings config.SveltinSettings
+	yamlFile, err := os.ReadFile(filepath.Join("..", "..", "resources", "sveltin.yaml"))
+	is.NoErr(err)
+	v := viper.New()
+	v.SetConfigType("yaml")
+	is.NoErr(v.ReadConfig(bytes.NewBuffer(yamlFile)))
+	is.NoErr(v.Unmarshal(&settings))
+
+	return pathmaker.NewSveltinPathMaker(&settings)
+}
@@ -9,8 +9,6 @@ package migrations
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
 
 	"github.com/tidwall/gjson"
 )
@@ -26,13 +24,3 @@ func getDevDependency(content []byte, name string) (string, bool) {
 	}
 	return "", false
 }
-
-func versionAsNum(text string) (float64, error) {
-	re := regexp.MustCompile(`(\d+\.\d+)`)
-	match := re.FindStringSubmatch(text)
-	if len(match) == 1 {
-		return strconv.ParseFloat(match[1], 64)
-	}
-	return 0, fmt.Errorf("something wrong parsing: %s", text)
-
-}
@@ -0,0 +1,184 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+// Package search builds the client-side search index for the content entries.
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// IndexVersion is the version of the search index format.
+const IndexVersion = 1
+
+// Indexed fields.
+const (
+	TitleField    string = "title"
+	HeadlineField string = "headline"
+	KeywordsField string = "keywords"
+	MetadataField string = "metadata"
+	BodyField     string = "body"
+)
+
+// Fields is the list of the indexed fields.
+var Fields = []string{TitleField, HeadlineField, KeywordsField, MetadataField, BodyField}
+
+// DefaultBoost returns the default boost by field.
+func DefaultBoost() map[string]int {
+	return map[string]int{
+		TitleField:    10,
+		HeadlineField: 5,
+		KeywordsField: 5,
+		MetadataField: 3,
+		BodyField:     1,
+	}
+}
+
+// ValidateBoost returns an error for unknown fields and negative values.
+func ValidateBoost(boost map[string]int) error {
+	for field, value := range boost {
+		known := false
+		for _, f := range Fields {
+			known = known || f == field
+		}
+		if !known {
+			return fmt.Errorf("%s is not a valid search field (%s)", field, strings.Join(Fields, ", "))
+		}
+		if value < 0 {
+			return fmt.Errorf("the boost for %s must be a positive number, 0 to skip the field", field)
+		}
+	}
+	return nil
+}
+
+// Document is the struct representing a content entry to be indexed.
+type Document struct {
+	URL      string
+	Title    string
+	Headline string
+	Resource string
+	// Date is the publication date as YYYY-MM-DD.
+	Date string
+	// Text is the text to be indexed by field.
+	Text map[string]string
+}
+
+// DocRef is the struct representing a document within the index, as shown in the search results.
+type DocRef struct {
+	URL      string `json:"url"`
+	Title    string `json:"title"`
+	Headline string `json:"headline,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	Date     string `json:"date,omitempty"`
+}
+
+// Index is the struct representing the search index saved as JSON.
+type Index struct {
+	Version int      `json:"version"`
+	Docs    []DocRef `json:"docs"`
+	// Terms maps the stemmed terms to the flattened list of document index and score pairs.
+	Terms map[string][]int `json:"terms"`
+}
+
+// Build returns the index for the documents. The score of a term for a document is the sum,
+// for each field, of the field boost times 1 + ln(term frequency).
+func Build(docs []*Document, boost map[string]int) *Index {
+	idx := &Index{Version: IndexVersion, Docs: []DocRef{}, Terms: make(map[string][]int)}
+	for i, d := range docs {
+		idx.Docs = append(idx.Docs, DocRef{URL: d.URL, Title: d.Title, Headline: d.Headline, Resource: d.Resource, Date: d.Date})
+
+		scores := make(map[string]float64)
+		for _, field := range Fields {
+			if boost[field] <= 0 || d.Text[field] == "" {
+				continue
+			}
+			frequencies := make(map[string]int)
+			for _, token := range Tokenize(d.Text[field]) {
+				frequencies[Stem(token)]++
+			}
+			for term, tf := range frequencies {
+				scores[term] += float64(boost[field]) * (1 + math.Log(float64(tf)))
+			}
+		}
+		for term, score := range scores {
+			idx.Terms[term] = append(idx.Terms[term], i, int(math.Max(1, math.Round(score))))
+		}
+	}
+	return idx
+}
+
+// Size returns the size in bytes of the index saved as JSON.
+func (idx *Index) Size() (int, error) {
+	data, err := json.Marshal(idx)
+	return len(data), err
+}
+
+// Prune removes the postings with the lowest score until the index fits in maxBytes.
+// It returns the number of removed postings, with an error when the documents alone exceed the budget.
+func (idx *Index) Prune(maxBytes int) (int, error) {
+	size, err := idx.Size()
+	if err != nil || maxBytes <= 0 || size <= maxBytes {
+		return 0, err
+	}
+
+	type posting struct {
+		term       string
+		doc, score int
+	}
+	postings := []posting{}
+	for term, values := range idx.Terms {
+		for i := 0; i+1 < len(values); i += 2 {
+			postings = append(postings, posting{term: term, doc: values[i], score: values[i+1]})
+		}
+	}
+	sort.Slice(postings, func(i, j int) bool {
+		if postings[i].score != postings[j].score {
+			return postings[i].score > postings[j].score
+		}
+		if postings[i].term != postings[j].term {
+			return postings[i].term < postings[j].term
+		}
+		return postings[i].doc < postings[j].doc
+	})
+
+	// keep the postings with the highest score, dropping 10% of them each round
+	kept := len(postings)
+	for size > maxBytes {
+		if kept == 0 {
+			return len(postings), fmt.Errorf("the search index exceeds %d bytes with no terms for %d documents, raise the size budget", maxBytes, len(idx.Docs))
+		}
+		kept -= int(math.Max(1, float64(kept)/10))
+		idx.Terms = make(map[string][]int)
+		for _, p := range postings[:kept] {
+			idx.Terms[p.term] = append(idx.Terms[p.term], p.doc, p.score)
+		}
+		for term, values := range idx.Terms {
+			sortPostings(values)
+			idx.Terms[term] = values
+		}
+		if size, err = idx.Size(); err != nil {
+			return 0, err
+		}
+	}
+	return len(postings) - kept, nil
+}
+
+// sortPostings sorts the flattened document and score pairs by document.
+func sortPostings(values []int) {
+	pairs := make([][2]int, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		pairs = append(pairs, [2]int{values[i], values[i+1]})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+	for i, p := range pairs {
+		values[2*i], values[2*i+1] = p[0], p[1]
+	}
+}
@@ -0,0 +1,79 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestStem(t *testing.T) {
+	is := is.New(t)
+
+	tests := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"cats":           "cat",
+		"agreed":         "agre",
+		"feed":           "feed",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"running":        "run",
+		"hopping":        "hop",
+		"falling":        "fall",
+		"filing":         "file",
+		"happy":          "happi",
+		"relational":     "relat",
+		"conditional":    "condit",
+		"generalization": "gener",
+		"connection":     "connect",
+		"adoption":       "adopt",
+		"hopefulness":    "hope",
+		"electrical":     "electr",
+		"controll":       "control",
+		"go":             "go",
+		"svelte5":        "svelte5",
+	}
+	for word, stem := range tests {
+		is.Equal(Stem(word), stem) // stem for word
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(Tokenize("The Quick, brown fox: go-1.20 and a café"), []string{"quick", "brown", "fox", "go", "20", "café"})
+}
+
+func TestBuildAndPrune(t *testing.T) {
+	is := is.New(t)
+
+	docs := []*Document{
+		{URL: "/posts/hello/", Title: "Hello", Text: map[string]string{TitleField: "Hello World", BodyField: "running and runners run"}},
+		{URL: "/posts/svelte/", Title: "Svelte", Text: map[string]string{TitleField: "Svelte", KeywordsField: "svelte, running"}},
+	}
+	idx := Build(docs, DefaultBoost())
+	is.Equal(idx.Version, IndexVersion)
+	is.Equal(len(idx.Docs), 2)
+	is.Equal(idx.Terms["hello"], []int{0, 10})
+	is.Equal(idx.Terms["run"], []int{0, 2, 1, 5}) // body tf 2, keyword
+	is.Equal(idx.Terms["runner"], []int{0, 1})
+
+	idx = Build(docs, map[string]int{TitleField: 1})
+	_, ok := idx.Terms["run"]
+	is.True(!ok) // body not indexed
+
+	idx = Build(docs, DefaultBoost())
+	size, err := idx.Size()
+	is.NoErr(err)
+	dropped, err := idx.Prune(size - 10)
+	is.NoErr(err)
+	is.True(dropped > 0)
+	is.Equal(idx.Terms["hello"], []int{0, 10}) // highest scores kept
+
+	_, err = idx.Prune(10)
+	is.True(err != nil)
+
+	is.NoErr(ValidateBoost(map[string]int{TitleField: 3}))
+	is.True(ValidateBoost(map[string]int{"summary": 3}) != nil)
+	is.True(ValidateBoost(map[string]int{BodyField: -1}) != nil)
+}
@@ -0,0 +1,42 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords are the English words not indexed.
+var stopWords = map[string]struct{}{}
+
+func init() {
+	for _, w := range strings.Fields(`a an and are as at be but by can do for from has have how if in
+		into is it its not of on or so such that the their then there these they this to was we were what
+		when where which who will with you your`) {
+		stopWords[w] = struct{}{}
+	}
+}
+
+// Tokenize returns the lowercase words in the text. Stop words and single characters are skipped.
+func Tokenize(text string) []string {
+	tokens := []string{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if len([]rune(w)) < 2 {
+			continue
+		}
+		if _, ok := stopWords[w]; ok {
+			continue
+		}
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
@@ -19,6 +19,10 @@ type ContentData struct {
 	Name     string
 	Resource string
 	Type     string
+	// Archetype is the name of the project archetype used for the content, empty for the embedded templates.
+	Archetype string
+	// Params are the values set with --set key=value, available to the archetypes.
+	Params map[string]string
 }
 
 // NewContentData creates a pointer to a ContentData struct.
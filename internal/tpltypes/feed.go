@@ -0,0 +1,42 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package tpltypes
+
+import "time"
+
+// FeedData is the struct representing a feed of the content entries for a sveltin project.
+type FeedData struct {
+	// ID is the permanent identifier for the feed (Atom and JSON Feed).
+	ID          string
+	Title       string
+	Description string
+	// Link is the URL to the website page the feed is about.
+	Link string
+	// FeedURL is the URL to the feed file.
+	FeedURL     string
+	Language    string
+	Copyright   string
+	AuthorName  string
+	AuthorEmail string
+	Updated     time.Time
+	FullContent bool
+	Items       []*FeedItem
+}
+
+// FeedItem is the struct representing a content entry within a feed.
+type FeedItem struct {
+	Title       string
+	Link        string
+	GUID        string
+	Description string
+	Content     string
+	Author      string
+	Categories  []string
+	Published   time.Time
+	Updated     time.Time
+}
@@ -7,15 +7,19 @@
 
 package tpltypes
 
-// MenuData is the struct representing a menu item.
+// MenuData is the struct representing the menu.
 type MenuData struct {
-	Items       *MenuItems
-	WithContent bool
+	Items []*MenuItem
 }
 
-// MenuItems is a struct representing a resource and its content as menu item.
-type MenuItems struct {
-	Name      string
-	Resources []string
-	Content   map[string][]string
+// MenuItem is the struct representing a menu item, as the Sveltin.MenuItem type.
+type MenuItem struct {
+	Identifier string
+	Name       string
+	URL        string
+	Weight     int
+	External   bool
+	// Depth is the nesting level of the item, 1 for the top level items.
+	Depth    int
+	Children []*MenuItem
 }
@@ -7,10 +7,22 @@
 
 package tpltypes
 
-// NoPageData is the struct representing a no-public page (sitemap and rss) for a sveltin project.
+import "github.com/sveltinio/sveltin/internal/search"
+
+// NoPageData is the struct representing a no-public page (sitemap, feeds, robots.txt and search index) for a sveltin project.
 type NoPageData struct {
 	Data  *ProjectSettings
 	Items *NoPageItems
+	// Feed is the feed to be rendered by the feed templates.
+	Feed *FeedData
+	// URLs are the entries to be rendered by the sitemap template.
+	URLs []*SitemapURL
+	// Sitemaps are the files to be rendered by the sitemap index template.
+	Sitemaps []*SitemapFile
+	// Robots is the data to be rendered by the robots.txt template.
+	Robots *RobotsTxtData
+	// Search is the index to be rendered by the search index template.
+	Search *search.Index
 }
 
 // NoPageItems is the struct representing an item
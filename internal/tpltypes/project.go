@@ -27,6 +27,10 @@ type ProjectSettings struct {
 	SvelteKit SvelteKitData  `mapstructure:"sveltekit" json:"sveltekit" validate:"required"`
 	Theme     ThemeData      `mapstructure:"theme" json:"theme" validate:"required"`
 	Sitemap   SitemapData    `mapstructure:"sitemap" json:"sitemap" validate:"required"`
+	Feeds     FeedsData      `mapstructure:"feeds" json:"feeds,omitempty"`
+	Robots    RobotsData     `mapstructure:"robots" json:"robots,omitempty"`
+	Menu      MenuSettings   `mapstructure:"menu" json:"menu,omitempty"`
+	Search    SearchData     `mapstructure:"search" json:"search,omitempty"`
 	Sveltin   SveltinCLIData `mapstructure:"sveltin" json:"sveltin" validate:"required"`
 }
 
@@ -45,7 +49,8 @@ type SvelteKitAdapterData struct {
 
 // SveltinCLIData is the struct used to map the sveltin cli props.
 type SveltinCLIData struct {
-	Version string `mapstructure:"version" json:"version" validate:"required,semver"`
+	Version    string   `mapstructure:"version" json:"version" validate:"required,semver"`
+	Migrations []string `mapstructure:"migrations" json:"migrations,omitempty"`
 }
 
 // SitemapData is the struct used to map the sitemap props.
@@ -53,3 +58,50 @@ type SitemapData struct {
 	ChangeFreq string  `mapstructure:"changeFreq" json:"changeFreq" validate:"required,oneof='always' 'hourly' 'daily' 'weekly' 'monthly' 'yearly' 'never'"`
 	Priority   float32 `mapstructure:"priority" json:"priority" validate:"required,numeric"`
 }
+
+// FeedsData is the struct used to map the feeds props.
+// They set which feeds are generated besides the one for the whole website.
+type FeedsData struct {
+	// Resources enables a feed for each resource (static/<resource>/rss.xml).
+	Resources bool `mapstructure:"resources" json:"resources"`
+	// Metadata enables a feed for each metadata value (static/<resource>/<metadata>/<value>/rss.xml).
+	Metadata bool `mapstructure:"metadata" json:"metadata"`
+}
+
+// RobotsData is the struct used to map the robots props.
+type RobotsData struct {
+	Rules []RobotsRule `mapstructure:"rules" json:"rules"`
+}
+
+// RobotsRule is the struct used to map a group of rules for the robots.txt file.
+type RobotsRule struct {
+	UserAgents []string `mapstructure:"userAgents" json:"userAgents"`
+	Allow      []string `mapstructure:"allow" json:"allow,omitempty"`
+	Disallow   []string `mapstructure:"disallow" json:"disallow,omitempty"`
+	CrawlDelay int      `mapstructure:"crawlDelay" json:"crawlDelay,omitempty"`
+}
+
+// SearchData is the struct used to map the search index props.
+type SearchData struct {
+	// Boost is the weight of each field (title, headline, keywords, metadata, body), 0 to skip it.
+	Boost map[string]int `mapstructure:"boost" json:"boost,omitempty"`
+	// MaxSize is the size budget for the search index in KB, no limit when 0.
+	MaxSize int `mapstructure:"maxSize" json:"maxSize,omitempty"`
+}
+
+// MenuSettings is the struct used to map the menu props.
+type MenuSettings struct {
+	Items []MenuItemSettings `mapstructure:"items" json:"items"`
+}
+
+// MenuItemSettings is the struct used to map the settings for a menu item.
+// Identifier is the route (e.g. posts or posts/tags), the content entry (e.g. posts/hello)
+// or, when URL is set, the identifier of a custom item.
+type MenuItemSettings struct {
+	Identifier string `mapstructure:"identifier" json:"identifier"`
+	Label      string `mapstructure:"label" json:"label,omitempty"`
+	URL        string `mapstructure:"url" json:"url,omitempty"`
+	Weight     int    `mapstructure:"weight" json:"weight,omitempty"`
+	Parent     string `mapstructure:"parent" json:"parent,omitempty"`
+	Hidden     bool   `mapstructure:"hidden" json:"hidden,omitempty"`
+}
@@ -0,0 +1,17 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package tpltypes
+
+// RobotsTxtData is the struct representing the robots.txt file for a sveltin project.
+type RobotsTxtData struct {
+	Rules []RobotsRule
+	// SitemapURL is the absolute url to the sitemap or the sitemap index.
+	SitemapURL string
+	// DisallowAll blocks all the crawlers (e.g. for a staging environment).
+	DisallowAll bool
+}
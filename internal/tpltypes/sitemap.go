@@ -0,0 +1,41 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package tpltypes
+
+import "time"
+
+// SitemapURL is the struct representing a url entry within the sitemap.
+type SitemapURL struct {
+	Loc        string
+	LastMod    time.Time
+	ChangeFreq string
+	Priority   float32
+	Images     []*SitemapImage
+	Alternates []*SitemapAlternate
+	// Group is the name of the sitemap file the url belongs to when using a sitemap index.
+	Group string
+}
+
+// SitemapImage is the struct representing an image (image:image) within a sitemap url entry.
+type SitemapImage struct {
+	Loc string
+}
+
+// SitemapAlternate is the struct representing a translation (xhtml:link) of a sitemap url entry.
+type SitemapAlternate struct {
+	HrefLang string
+	Href     string
+}
+
+// SitemapFile is the struct representing a sitemap file listed within the sitemap index.
+type SitemapFile struct {
+	Name    string
+	Loc     string
+	LastMod time.Time
+	URLs    []*SitemapURL
+}
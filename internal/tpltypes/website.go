@@ -0,0 +1,22 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package tpltypes
+
+// WebSiteData is the struct representing the website metadata set in config/website.js.ts.
+type WebSiteData struct {
+	Name         string
+	BaseURL      string
+	Language     string
+	Title        string
+	Description  string
+	Copyright    string
+	Logo         string
+	ContactEmail string
+	CreatorName  string
+	CreatorEmail string
+}
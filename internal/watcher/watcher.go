@@ -0,0 +1,202 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+// Package watcher implements a recursive and debounced file system watcher.
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDelay is the default time to wait for further changes before calling the handler.
+const DefaultDelay = 500 * time.Millisecond
+
+// Handler is called with the sorted list of the paths changed within the delay.
+type Handler func(paths []string)
+
+// Watcher is the struct representing a recursive watcher for a set of folders.
+type Watcher struct {
+	// Delay is the time to wait for further changes before calling the handler.
+	Delay time.Duration
+	// Ignore returns true for the paths whose changes must not trigger the handler.
+	Ignore func(path string) bool
+	// OnError is called for the errors reported by the underlying watcher.
+	OnError func(err error)
+
+	fsw     *fsnotify.Watcher
+	mu      sync.Mutex
+	pending map[string]struct{}
+	// files are the files watched by AddFile, fileFolders the folders watched for them only.
+	files       map[string]struct{}
+	fileFolders map[string]struct{}
+}
+
+// New returns a Watcher for the folders and their subfolders. Folders not existing are skipped.
+func New(folders ...string) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		Delay:       DefaultDelay,
+		Ignore:      IsTempFile,
+		fsw:         fsw,
+		pending:     make(map[string]struct{}),
+		files:       make(map[string]struct{}),
+		fileFolders: make(map[string]struct{}),
+	}
+	for _, folder := range folders {
+		if info, err := os.Stat(folder); err != nil || !info.IsDir() {
+			continue
+		}
+		if err := w.addRecursive(folder); err != nil {
+			fsw.Close()
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// AddFile watches the file. Its folder is watched, not recursively, so that the changes are
+// still reported when editors replace the file on save.
+func (w *Watcher) AddFile(path string) error {
+	path = filepath.Clean(path)
+	folder := filepath.Dir(path)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.files[path] = struct{}{}
+	for _, watched := range w.fsw.WatchList() {
+		if filepath.Clean(watched) == folder {
+			return nil
+		}
+	}
+	w.fileFolders[folder] = struct{}{}
+	return w.fsw.Add(folder)
+}
+
+// Watched returns the list of the watched folders.
+func (w *Watcher) Watched() []string {
+	return w.fsw.WatchList()
+}
+
+// Run calls the handler for the changes until the context is done. The watcher is closed on return.
+func (w *Watcher) Run(ctx context.Context, handler Handler) error {
+	defer w.fsw.Close()
+
+	timer := time.NewTimer(w.Delay)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return nil
+			}
+			if !w.handleEvent(event) {
+				continue
+			}
+			timer.Reset(w.Delay)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return nil
+			}
+			if w.OnError != nil {
+				w.OnError(err)
+			}
+		case <-timer.C:
+			if paths := w.flush(); len(paths) > 0 {
+				handler(paths)
+			}
+		}
+	}
+}
+
+// handleEvent records the path for the event and returns true when it must trigger the handler.
+// New folders are added to the watch list.
+func (w *Watcher) handleEvent(event fsnotify.Event) bool {
+	if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+		return false
+	}
+	if w.Ignore != nil && w.Ignore(event.Name) {
+		return false
+	}
+	if !w.isWatched(event.Name) {
+		return false
+	}
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			if err := w.addRecursive(event.Name); err != nil && w.OnError != nil {
+				w.OnError(err)
+			}
+		}
+	}
+
+	w.mu.Lock()
+	w.pending[event.Name] = struct{}{}
+	w.mu.Unlock()
+	return true
+}
+
+// isWatched returns false for the paths within the folders watched for AddFile only.
+func (w *Watcher) isWatched(path string) bool {
+	path = filepath.Clean(path)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.fileFolders[filepath.Dir(path)]; !ok {
+		return true
+	}
+	_, ok := w.files[path]
+	return ok
+}
+
+// flush returns the sorted pending paths and resets them.
+func (w *Watcher) flush() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	paths := make([]string, 0, len(w.pending))
+	for path := range w.pending {
+		paths = append(paths, path)
+	}
+	w.pending = make(map[string]struct{})
+	sort.Strings(paths)
+	return paths
+}
+
+func (w *Watcher) addRecursive(root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return w.fsw.Add(path)
+	})
+}
+
+// IsTempFile returns true for hidden files and the temporary files saved by editors
+// (e.g. .index.svx.swp, index.svx~, #index.svx#).
+func IsTempFile(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasPrefix(name, ".") ||
+		strings.HasSuffix(name, "~") ||
+		(strings.HasPrefix(name, "#") && strings.HasSuffix(name, "#"))
+}
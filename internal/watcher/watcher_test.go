@@ -0,0 +1,104 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestIsTempFile(t *testing.T) {
+	is := is.New(t)
+
+	is.True(IsTempFile("content/posts/.index.svx.swp"))
+	is.True(IsTempFile("content/posts/index.svx~"))
+	is.True(IsTempFile("content/posts/#index.svx#"))
+	is.True(!IsTempFile("content/posts/hello/index.svx"))
+}
+
+func TestRun(t *testing.T) {
+	is := is.New(t)
+
+	root := t.TempDir()
+	is.NoErr(os.MkdirAll(filepath.Join(root, "posts"), 0755))
+
+	w, err := New(root, filepath.Join(root, "missing"))
+	is.NoErr(err)
+	w.Delay = 100 * time.Millisecond
+	is.Equal(len(w.Watched()), 2) // root and posts
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	calls := make(chan []string, 10)
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx, func(paths []string) { calls <- paths })
+	}()
+
+	// a new folder is watched and the changes within the delay are reported at once
+	entry := filepath.Join(root, "posts", "hello")
+	is.NoErr(os.Mkdir(entry, 0755))
+	time.Sleep(20 * time.Millisecond)
+	is.NoErr(os.WriteFile(filepath.Join(entry, "index.svx"), []byte("---\n---\n"), 0644))
+	is.NoErr(os.WriteFile(filepath.Join(entry, "index.svx~"), []byte(""), 0644))
+
+	select {
+	case paths := <-calls:
+		is.Equal(paths, []string{entry, filepath.Join(entry, "index.svx")})
+	case <-ctx.Done():
+		t.Fatal("handler not called")
+	}
+
+	select {
+	case paths := <-calls:
+		t.Fatalf("unexpected call with %v", paths)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	cancel()
+	is.NoErr(<-done)
+}
+
+func TestAddFile(t *testing.T) {
+	is := is.New(t)
+
+	root := t.TempDir()
+	settings := filepath.Join(root, "sveltin.json")
+	is.NoErr(os.WriteFile(settings, []byte("{}"), 0644))
+
+	w, err := New()
+	is.NoErr(err)
+	w.Delay = 100 * time.Millisecond
+	is.NoErr(w.AddFile(settings))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	calls := make(chan []string, 10)
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx, func(paths []string) { calls <- paths })
+	}()
+
+	// other files and folders within the folder are not reported, the file is after being replaced
+	is.NoErr(os.Mkdir(filepath.Join(root, "node_modules"), 0755))
+	is.NoErr(os.WriteFile(filepath.Join(root, "package.json"), []byte("{}"), 0644))
+	tmp := filepath.Join(root, "sveltin.json.tmp")
+	is.NoErr(os.WriteFile(tmp, []byte(`{"name": "site"}`), 0644))
+	is.NoErr(os.Rename(tmp, settings))
+
+	select {
+	case paths := <-calls:
+		is.Equal(paths, []string{settings})
+	case <-ctx.Done():
+		t.Fatal("handler not called")
+	}
+	is.Equal(len(w.Watched()), 1) // node_modules not added
+
+	cancel()
+	is.NoErr(<-done)
+}
@@ -102,12 +102,18 @@ var ContentFilesMap = EmbeddedFSEntry{
 	"sample": "internal/templates/content/sample.svx.gotxt",
 }
 
-// XMLFilesMap is a map for the xml (sitemap and rss) template files.
+// XMLFilesMap is a map for the no-page (sitemap, feeds, robots.txt and search) template files.
 var XMLFilesMap = EmbeddedFSEntry{
-	"sitemap_static": "internal/templates/xml/sitemap.xml.gotxt",
-	"rss_static":     "internal/templates/xml/rss.xml.gotxt",
-	"sitemap_ssr":    "internal/templates/xml/ssr_sitemap.xml.ts.gotxt",
-	"rss_ssr":        "internal/templates/xml/ssr_rss.xml.ts.gotxt",
+	"sitemap_static":       "internal/templates/xml/sitemap.xml.gotxt",
+	"sitemap_index_static": "internal/templates/xml/sitemap_index.xml.gotxt",
+	"rss_static":           "internal/templates/xml/rss.xml.gotxt",
+	"atom_static":          "internal/templates/xml/atom.xml.gotxt",
+	"jsonfeed_static":      "internal/templates/xml/feed.json.gotxt",
+	"robots_static":        "internal/templates/xml/robots.txt.gotxt",
+	"search_static":        "internal/templates/xml/search.json.gotxt",
+	"search_lib":           "internal/templates/xml/search.ts.gotxt",
+	"sitemap_ssr":          "internal/templates/xml/ssr_sitemap.xml.ts.gotxt",
+	"rss_ssr":              "internal/templates/xml/ssr_rss.xml.ts.gotxt",
 }
 
 //=============================================================================
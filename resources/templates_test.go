@@ -74,6 +74,12 @@ func TestSveltinXMLFS(t *testing.T) {
 	is.Equal("internal/templates/xml/ssr_sitemap.xml.ts.gotxt", XMLFilesMap["sitemap_ssr"])
 	is.Equal("internal/templates/xml/rss.xml.gotxt", XMLFilesMap["rss_static"])
 	is.Equal("internal/templates/xml/ssr_rss.xml.ts.gotxt", XMLFilesMap["rss_ssr"])
+	is.Equal("internal/templates/xml/sitemap_index.xml.gotxt", XMLFilesMap["sitemap_index_static"])
+	is.Equal("internal/templates/xml/atom.xml.gotxt", XMLFilesMap["atom_static"])
+	is.Equal("internal/templates/xml/feed.json.gotxt", XMLFilesMap["jsonfeed_static"])
+	is.Equal("internal/templates/xml/robots.txt.gotxt", XMLFilesMap["robots_static"])
+	is.Equal("internal/templates/xml/search.json.gotxt", XMLFilesMap["search_static"])
+	is.Equal("internal/templates/xml/search.ts.gotxt", XMLFilesMap["search_lib"])
 }
 
 func TestBootstrapThemeFS(t *testing.T) {
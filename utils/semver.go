@@ -0,0 +1,144 @@
+/**
+ * Copyright © 2021-present Sveltin contributors <github@sveltin.io>
+ *
+ * Use of this source code is governed by Apache 2.0 license
+ * that can be found in the LICENSE file.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SemVer is the struct representing a semantic version number.
+type SemVer struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease string
+}
+
+// ParseSemVer returns a SemVer struct for the version string (e.g. 0.11.0, v1.2.3-beta.1).
+// An empty string is parsed as 0.0.0.
+func ParseSemVer(version string) (*SemVer, error) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if v == "" {
+		return &SemVer{}, nil
+	}
+
+	// build metadata does not take part in the precedence.
+	if i := strings.Index(v, "+"); i != -1 {
+		v = v[:i]
+	}
+
+	semver := &SemVer{}
+	if i := strings.Index(v, "-"); i != -1 {
+		semver.PreRelease = v[i+1:]
+		v = v[:i]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("not a valid semantic version: %s", version)
+	}
+
+	numbers := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("not a valid semantic version: %s", version)
+		}
+		numbers[i] = n
+	}
+	semver.Major, semver.Minor, semver.Patch = numbers[0], numbers[1], numbers[2]
+
+	return semver, nil
+}
+
+// String returns the version as string.
+func (v *SemVer) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		s = s + "-" + v.PreRelease
+	}
+	return s
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower than, equal to
+// or greater than other.
+func (v *SemVer) Compare(other *SemVer) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+// CompareSemVer parses and compares two version strings.
+// It returns -1, 0 or +1 depending on whether v1 is lower than, equal to or greater than v2.
+func CompareSemVer(v1, v2 string) (int, error) {
+	sv1, err := ParseSemVer(v1)
+	if err != nil {
+		return 0, err
+	}
+	sv2, err := ParseSemVer(v2)
+	if err != nil {
+		return 0, err
+	}
+	return sv1.Compare(sv2), nil
+}
+
+//=============================================================================
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePreRelease follows the semver precedence rules: a version without
+// pre-release has higher precedence, numeric identifiers are compared numerically.
+func comparePreRelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	aIds, bIds := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aIds) && i < len(bIds); i++ {
+		aNum, aErr := strconv.Atoi(aIds[i])
+		bNum, bErr := strconv.Atoi(bIds[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareInt(aNum, bNum); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(aIds[i], bIds[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(len(aIds), len(bIds))
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseSemVer(t *testing.T) {
+	is := is.New(t)
+
+	v, err := ParseSemVer("v0.11.2-beta.1+build.5")
+	is.NoErr(err)
+	is.Equal(&SemVer{Major: 0, Minor: 11, Patch: 2, PreRelease: "beta.1"}, v)
+	is.Equal("0.11.2-beta.1", v.String())
+
+	v, err = ParseSemVer("")
+	is.NoErr(err)
+	is.Equal("0.0.0", v.String())
+
+	_, err = ParseSemVer("0.11")
+	is.True(err != nil)
+
+	_, err = ParseSemVer("a.b.c")
+	is.True(err != nil)
+}
+
+func TestCompareSemVer(t *testing.T) {
+	is := is.New(t)
+
+	tests := []struct {
+		v1   string
+		v2   string
+		want int
+	}{
+		{"0.11.0", "0.11.0", 0},
+		{"0.10.1", "0.11.0", -1},
+		{"1.0.0", "0.11.0", 1},
+		{"0.11.0-rc.1", "0.11.0", -1},
+		{"0.11.0-alpha", "0.11.0-alpha.1", -1},
+		{"0.11.0-alpha.2", "0.11.0-alpha.10", -1},
+		{"0.11.0-beta", "0.11.0-alpha", 1},
+		{"", "0.1.0", -1},
+	}
+
+	for _, tc := range tests {
+		got, err := CompareSemVer(tc.v1, tc.v2)
+		is.NoErr(err)
+		is.Equal(tc.want, got)
+	}
+}